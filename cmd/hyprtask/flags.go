@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/cli"
+	"github.com/paulvinueza30/hyprtask/internal/config"
+)
+
+// options holds the command line flags. Flags that are set override the config file.
+type options struct {
+	configPath         string
+	printDefaultConfig bool
+	pollInterval       time.Duration
+	sampleWindow       time.Duration
+	screen             string
+	sort               string
+	noDaemon           bool
+	replay             string
+	batch              bool
+	batchOptions       cli.BatchOptions
+}
+
+func parseFlags() options {
+	var opts options
+	flag.StringVar(&opts.configPath, "config", "", "config file (default "+config.Path()+")")
+	flag.BoolVar(&opts.printDefaultConfig, "print-default-config", false, "print a commented starter config and exit")
+	flag.DurationVar(&opts.pollInterval, "poll-interval", 0, "refresh interval, e.g. 2s")
+	flag.DurationVar(&opts.sampleWindow, "sample-window", 0, "CPU and I/O sampling window, shorter than the poll interval")
+	flag.StringVar(&opts.screen, "screen", "", "screen shown at startup: workspaces or processes")
+	flag.StringVar(&opts.sort, "sort", "", "initial sort as key[:order], e.g. cpu:desc")
+	flag.BoolVar(&opts.noDaemon, "no-daemon", false, "poll processes here even when hyprtask daemon runs")
+	flag.StringVar(&opts.replay, "replay", "", "show a recording made with hyprtask record instead of live processes")
+	flag.BoolVar(&opts.batch, "batch", false, "print plain text frames to stdout instead of starting the UI, like top -b")
+	flag.IntVar(&opts.batchOptions.Iterations, "iterations", 0, "with --batch, stop after `n` frames, 0 to run until interrupted")
+	flag.DurationVar(&opts.batchOptions.Interval, "interval", 0, "with --batch, time between frames (default the poll interval)")
+	flag.IntVar(&opts.batchOptions.Limit, "limit", 20, "with --batch, processes per frame, 0 for all")
+	flag.StringVar(&opts.batchOptions.Filter, "filter", "", "with --batch, only processes whose name or command line contains `text`")
+	flag.StringVar(&opts.batchOptions.Workspace, "workspace", "", "with --batch, only the windows of the workspace with this `name or ID`")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\nFlags:\n", cli.UsageLine)
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr)
+		cli.Usage(os.Stderr)
+	}
+	flag.Parse()
+	return opts
+}
+
+func (o options) apply(cfg *config.Config) {
+	if o.pollInterval != 0 {
+		cfg.PollInterval.Duration = o.pollInterval
+	}
+	if o.sampleWindow != 0 {
+		cfg.SampleWindow.Duration = o.sampleWindow
+	}
+	if o.screen != "" {
+		cfg.DefaultScreen = o.screen
+	}
+	if o.noDaemon {
+		cfg.UseDaemon = false
+	}
+	if o.sort != "" {
+		key, order, found := strings.Cut(o.sort, ":")
+		cfg.Sort.Key = key
+		cfg.Sort.Order = "none"
+		if found {
+			cfg.Sort.Order = order
+		}
+	}
+}
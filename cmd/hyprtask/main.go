@@ -1,36 +1,146 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/paulvinueza30/hyprtask/internal/cli"
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/daemon"
+	"github.com/paulvinueza30/hyprtask/internal/hooks"
 	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/notify"
+	"github.com/paulvinueza30/hyprtask/internal/recording"
 	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
 	"github.com/paulvinueza30/hyprtask/internal/ui"
+	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
+	"github.com/paulvinueza30/hyprtask/internal/ui/screens/processlist"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
 	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+	"github.com/paulvinueza30/hyprtask/internal/watchdog"
 )
 
 func main() {
+	opts := parseFlags()
+
+	choices := config.Choices{
+		Columns:         processlist.ColumnIDs(),
+		SortableColumns: processlist.SortableColumnIDs(),
+		Actions:         keymap.ActionNames(),
+		KeyPresets:      keymap.PresetNames(),
+		SortKeys:        viewmodel.SortKeyNames(),
+		Themes:          theme.Names(),
+		HookEvents:      hooks.EventNames(),
+		ParseSignal:     taskmanager.ParseSignal,
+	}
+	if opts.printDefaultConfig {
+		if err := config.WriteDefault(os.Stdout, choices, processlist.DefaultColumnIDs(), keymap.DefaultBindings(keymap.PresetDefault)); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		fatal(err)
+	}
+	opts.apply(&cfg)
+	if err := cfg.Validate(choices); err != nil {
+		fatal(err)
+	}
+	if args := flag.Args(); len(args) > 0 {
+		os.Exit(cli.Run(args, cfg))
+	}
+	if opts.batch {
+		os.Exit(cli.RunBatch(cfg, opts.batchOptions))
+	}
+	if _, err := keymap.New(cfg.KeyPreset, cfg.Keys); err != nil {
+		fatal(fmt.Errorf("invalid key bindings in %s:\n%w", cfg.Path, err))
+	}
+	if _, err := theme.Resolve(cfg.Theme.Name, cfg.ThemesDir()); err != nil {
+		fatal(err)
+	}
+	viewOptions, err := viewmodel.ParseViewOptions(cfg.Sort.Key, cfg.Sort.Order)
+	if err != nil {
+		fatal(err)
+	}
+	var player *recording.Player
+	if opts.replay != "" {
+		rec, err := recording.Load(opts.replay)
+		if err != nil {
+			fatal(err)
+		}
+		player = recording.NewPlayer(rec)
+	}
+
 	logger.Init()
 
 	snapshotChan := make(chan taskmanager.Snapshot, 3)
 	taskActionChan := make(chan taskmanager.TaskAction, 10)
-	tm, err := taskmanager.NewTaskManager(5*time.Second, snapshotChan, taskActionChan)
-	if err != nil {
-		return
-	}
+	actionResultChan := make(chan taskmanager.ActionResult, 10)
 	viewActionChan := make(chan viewmodel.ViewAction, 1)
 	displayDataChan := make(chan viewmodel.DisplayData, 1)
-	vm := viewmodel.NewViewModel(snapshotChan, viewActionChan, displayDataChan)
-	go tm.Start()
+	vm := viewmodel.NewViewModel(snapshotChan, viewActionChan, displayDataChan, viewOptions)
+	if player != nil {
+		go player.Serve(snapshotChan, taskActionChan, actionResultChan)
+	} else if client := dialDaemon(cfg); client != nil {
+		if store, err := client.History(); err == nil {
+			vm.UseHistory(store)
+		} else {
+			logger.Log.Warn("could not get the daemon's history", "error", err)
+		}
+		go client.Serve(snapshotChan, taskActionChan, actionResultChan)
+	} else {
+		tm, err := taskmanager.NewTaskManager(cfg.PollInterval.Duration, cfg.SampleWindow.Duration, snapshotChan, taskActionChan, actionResultChan)
+		if err != nil {
+			logger.Log.Error("could not create task manager", "error", err)
+			fatal(err)
+		}
+		notifier, err := notify.Start(cfg.Notifications, tm)
+		if err != nil {
+			logger.Log.Warn("desktop notifications are off", "error", err)
+		}
+		if err := watchdog.Start(cfg.Watchdog, tm, notifier); err != nil {
+			fatal(err)
+		}
+		if err := hooks.Start(cfg.Hooks, tm); err != nil {
+			fatal(err)
+		}
+		go tm.Start()
+	}
 	go vm.Start()
 
-	m := ui.NewModel(displayDataChan, viewActionChan, taskActionChan)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	
+	m := ui.NewModel(cfg, displayDataChan, viewActionChan, taskActionChan, actionResultChan)
+	if player != nil {
+		m.UseReplay(player)
+	}
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+
 	if _, err := p.Run(); err != nil {
 		logger.Log.Error("could not start program", "error", err)
 		os.Exit(1)
 	}
 }
+
+// dialDaemon returns a client of the running daemon, nil when there is none
+// or the config turns it off
+func dialDaemon(cfg config.Config) *daemon.Client {
+	if !cfg.UseDaemon {
+		return nil
+	}
+	client, err := daemon.Dial(daemon.SocketPath())
+	if err != nil {
+		logger.Log.Info("no daemon, polling processes here", "error", err)
+		return nil
+	}
+	logger.Log.Info("reading from the daemon", "socket", daemon.SocketPath())
+	return client
+}
+
+// fatal reports errors that happen before the UI starts on stderr, where the user sees them
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "hyprtask:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+const defaultBarFormat = "{workspace} {cpu}% {mem}%"
+
+// barOutput is one update of a Waybar custom module with "return-type": "json"
+type barOutput struct {
+	Text       string `json:"text"`
+	Tooltip    string `json:"tooltip"`
+	Class      string `json:"class"`
+	Percentage int    `json:"percentage"`
+}
+
+// barFlags configure the text and the thresholds of the module
+type barFlags struct {
+	format   string
+	metric   string
+	warning  float64
+	critical float64
+	top      int
+}
+
+func runBar(env *env, fs *flag.FlagSet, args []string) error {
+	var bar barFlags
+	interval := fs.Duration("interval", env.cfg.PollInterval.Duration, "`interval` between updates")
+	sampleWindow := fs.Duration("sample-window", env.cfg.SampleWindow.Duration, "how long CPU and I/O are measured")
+	fs.StringVar(&bar.format, "format", defaultBarFormat,
+		"`text` of the module. Placeholders: {workspace}, {cpu}, {mem}, {windows}, {sys_cpu}, {sys_mem}")
+	fs.StringVar(&bar.metric, "metric", "cpu", "`cpu or mem` of the workspace, for the percentage and the thresholds")
+	fs.Float64Var(&bar.warning, "warning", 50, "`percent` from which the class is \"warning\"")
+	fs.Float64Var(&bar.critical, "critical", 80, "`percent` from which the class is \"critical\"")
+	fs.IntVar(&bar.top, "top", 5, "list the `n` busiest processes of the workspace in the tooltip")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if bar.metric != "cpu" && bar.metric != "mem" {
+		return usageErrorf("unknown metric %q, expected cpu or mem", bar.metric)
+	}
+	if bar.top < 0 {
+		return usageErrorf("--top cannot be negative")
+	}
+	if bar.warning > bar.critical {
+		return usageErrorf("the warning threshold (%g) is above the critical one (%g)", bar.warning, bar.critical)
+	}
+	hyprland := hypr.NewHyprlandClient()
+	if !hyprland.Available() {
+		return errNoHyprland
+	}
+
+	opts := viewmodel.ViewOptions{SortKey: viewmodel.SortByCPU, SortOrder: viewmodel.OrderDESC}
+	if bar.metric == "mem" {
+		opts.SortKey = viewmodel.SortByMEM
+	}
+	s, err := startSession(*interval, fitSampleWindow(fs, *sampleWindow, *interval), opts, env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+
+	// Waybar reads one JSON object per line for as long as the module runs
+	enc := json.NewEncoder(env.stdout)
+	for {
+		data, err := s.next(true)
+		if err != nil {
+			return err
+		}
+		active, err := hyprland.ActiveWorkspace()
+		if err != nil {
+			// keep the module alive, Hyprland may be restarting
+			fmt.Fprintf(env.stderr, "hyprtask bar: %v\n", err)
+			continue
+		}
+		if err := enc.Encode(bar.render(data, active)); err != nil {
+			return err
+		}
+	}
+}
+
+// render builds the module for the active workspace. A workspace without
+// windows is not in data and shows zeros.
+func (b barFlags) render(data viewmodel.DisplayData, active hypr.Workspace) barOutput {
+	ws, ok := data.Hypr.WorkspaceToProcs[active.ID]
+	if !ok {
+		ws = &viewmodel.WorkspaceData{WorkspaceID: active.ID, WorkspaceName: active.Name}
+	}
+	value := ws.TotalCPU
+	if b.metric == "mem" {
+		value = ws.TotalMEM
+	}
+
+	class := "normal"
+	switch {
+	case value >= b.critical:
+		class = "critical"
+	case value >= b.warning:
+		class = "warning"
+	}
+	return barOutput{
+		Text:       b.text(ws, data.System.CPU, data.System.MEM),
+		Tooltip:    b.tooltip(ws),
+		Class:      class,
+		Percentage: int(math.Round(min(max(value, 0), 100))),
+	}
+}
+
+// text fills in the format. Waybar reads it as Pango markup too, which the
+// format may use, so only the values are escaped.
+func (b barFlags) text(ws *viewmodel.WorkspaceData, sysCPU, sysMEM float64) string {
+	return strings.NewReplacer(
+		"{workspace}", html.EscapeString(ws.WorkspaceName),
+		"{cpu}", fmt.Sprintf("%.0f", ws.TotalCPU),
+		"{mem}", fmt.Sprintf("%.0f", ws.TotalMEM),
+		"{windows}", fmt.Sprint(ws.ActiveProcsCount),
+		"{sys_cpu}", fmt.Sprintf("%.0f", sysCPU),
+		"{sys_mem}", fmt.Sprintf("%.0f", sysMEM),
+	).Replace(b.format)
+}
+
+// tooltip lists the busiest processes of ws. Waybar renders it as Pango
+// markup, so names are escaped.
+func (b barFlags) tooltip(ws *viewmodel.WorkspaceData) string {
+	lines := []string{fmt.Sprintf("Workspace %s: CPU %.1f%%, memory %.1f%%",
+		html.EscapeString(ws.WorkspaceName), ws.TotalCPU, ws.TotalMEM)}
+	procs := ws.ActiveProcs
+	if len(procs) > b.top {
+		procs = procs[:b.top]
+	}
+	for _, p := range procs {
+		lines = append(lines, fmt.Sprintf("%5.1f%% %5.1f%%  %s", p.Metrics.CPU, p.Metrics.MEM, html.EscapeString(barLabel(p))))
+	}
+	if len(ws.ActiveProcs) == 0 {
+		lines = append(lines, "no windows")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// barLabel names a window by class and title, e.g. "kitty: vim"
+func barLabel(p taskmanager.TaskProcess) string {
+	if p.Meta == nil || p.Meta.Hyprland == nil {
+		return p.ProgramName
+	}
+	w := p.Meta.Hyprland
+	if w.Title == "" {
+		return w.Class
+	}
+	return w.Class + ": " + w.Title
+}
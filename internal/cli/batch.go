@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+// BatchOptions are the flags of "hyprtask --batch"
+type BatchOptions struct {
+	Iterations int           // 0 to run until interrupted
+	Interval   time.Duration // 0 for the poll interval
+	Limit      int           // processes per frame, 0 for all
+	Filter     string        // only processes whose name or command line contains it, like marking by filter in the UI
+	Workspace  string        // only the windows of the workspace with this name or ID, like opening it in the UI
+}
+
+// RunBatch prints plain text frames like "top -b", for cron jobs and log
+// files, and returns the exit status. Frames are sorted and show the columns
+// of the UI's config, where the command line knows them.
+func RunBatch(cfg config.Config, opts BatchOptions) int {
+	env := newEnv(cfg)
+	f := frames{
+		iterations:   opts.Iterations,
+		interval:     opts.Interval,
+		sampleWindow: cfg.SampleWindow.Duration,
+		sel:          selector{match: opts.Filter, workspace: opts.Workspace},
+		limit:        opts.Limit,
+		header:       true,
+	}
+	if f.interval == 0 {
+		f.interval = cfg.PollInterval.Duration
+	}
+	if f.sampleWindow >= f.interval {
+		f.sampleWindow = f.interval * 4 / 5
+	}
+	f.cols = batchColumns(cfg.Columns)
+
+	// like the UI, the config's sort applies; without one the busiest processes come first
+	sort, err := viewmodel.ParseViewOptions(cfg.Sort.Key, cfg.Sort.Order)
+	if err != nil || sort.SortKey == viewmodel.SortByNone {
+		sort = viewmodel.ViewOptions{SortKey: viewmodel.SortByCPU, SortOrder: viewmodel.OrderDESC}
+	}
+	f.sort = sort
+
+	if opts.Limit < 0 {
+		return env.exit("--batch", usageErrorf("--limit cannot be negative"))
+	}
+	return env.exit("--batch", f.print(env))
+}
+
+// batchColumns picks the UI's configured columns that the commands can print,
+// falling back to top's
+func batchColumns(ids []string) []column {
+	var cols []column
+	for _, id := range ids {
+		if col, ok := lookupColumn(id); ok {
+			cols = append(cols, col)
+		}
+	}
+	if len(cols) == 0 {
+		cols, _ = parseColumns(defaultTopColumns)
+	}
+	return cols
+}
@@ -0,0 +1,165 @@
+// Package cli implements the non-interactive subcommands, such as "hyprtask ps",
+// for shell scripts and Hyprland key bindings.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+)
+
+// command is one subcommand
+type command struct {
+	name    string
+	args    string // arguments after the flags, for the usage line
+	summary string
+	run     func(env *env, fs *flag.FlagSet, args []string) error // fs is empty, for the command's flags
+}
+
+// env is what every command runs with
+type env struct {
+	cfg    config.Config
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var commands = []command{
+	{name: "ps", summary: "list processes", run: runPS},
+	{name: "workspaces", summary: "list Hyprland workspaces and their load", run: runWorkspaces},
+	{name: "kill", args: "[pid...]", summary: "signal processes by PID, workspace or window class", run: runKill},
+	{name: "top", summary: "print the busiest processes, repeatedly", run: runTop},
+	{name: "bar", summary: "feed a Waybar custom module with the active workspace's load", run: runBar},
+	{name: "exporter", summary: "serve workspace and process group usage to Prometheus (OpenMetrics)", run: runExporter},
+	{name: "daemon", summary: "poll once for every view, which connect over a Unix socket", run: runDaemon},
+	{name: "record", summary: "record snapshots to a file for hyprtask --replay", run: runRecord},
+	{name: "snapshot", summary: "write processes and workspaces as JSON, once or as a stream", run: runSnapshot},
+}
+
+// usageError is a mistake in the command line, reported with the usage
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// errFlags reports flags the flag set could not parse. It has already printed
+// the mistake and the usage.
+var errFlags = errors.New("invalid flags")
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+func lookup(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// newEnv prepares the process for running without the UI
+func newEnv(cfg config.Config) *env {
+	// log files would land in whatever directory a script or key binding runs
+	// in. The daemon opens its own under the state directory.
+	logger.InitDiscard()
+	// writes to a closed pipe, as in "hyprtask ps | head", return EPIPE instead of killing the process
+	signal.Ignore(syscall.SIGPIPE)
+	return &env{cfg: cfg, stdout: os.Stdout, stderr: os.Stderr}
+}
+
+// Run runs the subcommand named by args[0] and returns the exit status
+func Run(args []string, cfg config.Config) int {
+	env := newEnv(cfg)
+
+	if len(args) == 0 || args[0] == "help" {
+		fmt.Fprintf(env.stderr, "usage: %s\n\n", UsageLine)
+		Usage(env.stderr)
+		return 0
+	}
+	cmd, ok := lookup(args[0])
+	if !ok {
+		fmt.Fprintf(env.stderr, "hyprtask: unknown command %q\n", args[0])
+		Usage(env.stderr)
+		return 2
+	}
+
+	return env.exit(cmd.name, cmd.run(env, newFlagSet(env, cmd), args[1:]))
+}
+
+// exit reports err of the command or flag name and returns the exit status
+func (env *env) exit(name string, err error) int {
+	var usage usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, syscall.EPIPE):
+		// the reader has all it wanted
+		return 0
+	case errors.Is(err, errFlags):
+		return 2
+	case errors.As(err, &usage):
+		help := "hyprtask -h"
+		if _, ok := lookup(name); ok {
+			help = "hyprtask " + name + " -h"
+		}
+		fmt.Fprintf(env.stderr, "hyprtask %s: %v\n", name, err)
+		fmt.Fprintf(env.stderr, "run '%s' for usage\n", help)
+		return 2
+	default:
+		fmt.Fprintf(env.stderr, "hyprtask %s: %v\n", name, err)
+		return 1
+	}
+}
+
+// UsageLine is the synopsis of hyprtask with and without a command
+const UsageLine = "hyprtask [flags] [command [command flags]]"
+
+// Usage lists the commands
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "Without a command the interactive UI starts. Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-11s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\nRun 'hyprtask <command> -h' for the flags of a command.")
+}
+
+// newFlagSet returns a flag set for cmd that reports errors instead of exiting
+func newFlagSet(env *env, cmd command) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(env.stderr, "usage: %s\n\n%s.\n\nFlags:\n", strings.TrimSpace("hyprtask "+cmd.name+" [flags] "+cmd.args), capitalize(cmd.summary))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args, returning flag.ErrHelp for -h and errFlags for mistakes
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return errFlags
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+// column is one column of the process table. IDs match the process list's.
+type column struct {
+	id     string
+	header string
+	value  func(p taskmanager.TaskProcess) string
+}
+
+var columns = []column{
+	{"pid", "PID", func(p taskmanager.TaskProcess) string { return strconv.Itoa(p.PID) }},
+	{"ppid", "PPID", func(p taskmanager.TaskProcess) string { return strconv.Itoa(p.PPID) }},
+	{"program", "PROGRAM", func(p taskmanager.TaskProcess) string { return p.ProgramName }},
+	{"user", "USER", func(p taskmanager.TaskProcess) string { return p.User }},
+	{"state", "S", func(p taskmanager.TaskProcess) string { return p.State }},
+	{"threads", "THR", func(p taskmanager.TaskProcess) string { return strconv.Itoa(p.Threads) }},
+	{"nice", "NI", func(p taskmanager.TaskProcess) string { return strconv.Itoa(p.Nice) }},
+	{"priority", "PRI", func(p taskmanager.TaskProcess) string { return strconv.Itoa(p.Priority) }},
+	{"elapsed", "ELAPSED", func(p taskmanager.TaskProcess) string { return formatElapsed(p.StartTime) }},
+	{"tty", "TTY", func(p taskmanager.TaskProcess) string { return orDash(p.TTY) }},
+	{"command", "COMMAND", func(p taskmanager.TaskProcess) string { return p.CommandLine }},
+	{"exe", "EXE", func(p taskmanager.TaskProcess) string { return orDash(p.Exe) }},
+	{"unit", "UNIT", func(p taskmanager.TaskProcess) string { return orDash(p.Unit) }},
+	{"workspace", "WS", func(p taskmanager.TaskProcess) string {
+		return windowField(p, func(w *hypr.HyprlandMeta) string { return w.Workspace.Name })
+	}},
+	{"monitor", "MON", func(p taskmanager.TaskProcess) string {
+		return windowField(p, func(w *hypr.HyprlandMeta) string { return strconv.Itoa(w.Monitor) })
+	}},
+	{"class", "CLASS", func(p taskmanager.TaskProcess) string {
+		return windowField(p, func(w *hypr.HyprlandMeta) string { return w.Class })
+	}},
+	{"title", "TITLE", func(p taskmanager.TaskProcess) string {
+		return windowField(p, func(w *hypr.HyprlandMeta) string { return w.Title })
+	}},
+	{"cpu", "CPU%", func(p taskmanager.TaskProcess) string { return formatPercent(p.Metrics.CPU) }},
+	{"mem", "MEM%", func(p taskmanager.TaskProcess) string { return formatPercent(p.Metrics.MEM) }},
+	{"read", "READ/s", func(p taskmanager.TaskProcess) string { return formatBytes(uint64(p.Metrics.IORead)) }},
+	{"write", "WRITE/s", func(p taskmanager.TaskProcess) string { return formatBytes(uint64(p.Metrics.IOWrite)) }},
+}
+
+const (
+	defaultPSColumns  = "pid,user,cpu,mem,workspace,program,command"
+	defaultTopColumns = "pid,user,state,cpu,mem,read,write,workspace,program"
+)
+
+func columnIDs() string {
+	ids := make([]string, len(columns))
+	for i, col := range columns {
+		ids[i] = col.id
+	}
+	return strings.Join(ids, ",")
+}
+
+// parseColumns resolves a comma separated list of column IDs
+func parseColumns(list string) ([]column, error) {
+	var picked []column
+	for _, id := range strings.Split(list, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		col, ok := lookupColumn(id)
+		if !ok {
+			return nil, usageErrorf("unknown column %q (valid: %s)", id, columnIDs())
+		}
+		picked = append(picked, col)
+	}
+	if len(picked) == 0 {
+		return nil, usageErrorf("no columns given")
+	}
+	return picked, nil
+}
+
+func lookupColumn(id string) (column, bool) {
+	for _, col := range columns {
+		if col.id == id {
+			return col, true
+		}
+	}
+	return column{}, false
+}
+
+// writeTable prints procs as aligned columns. Tabs and newlines inside values,
+// common in titles and command lines, are replaced so rows stay on one line.
+func writeTable(w io.Writer, procs []taskmanager.TaskProcess, cols []column, header bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if header {
+		headers := make([]string, len(cols))
+		for i, col := range cols {
+			headers[i] = col.header
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+	cells := make([]string, len(cols))
+	for _, p := range procs {
+		for i, col := range cols {
+			cells[i] = cleanCell(col.value(p))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+var cellReplacer = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+func cleanCell(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return cellReplacer.Replace(s)
+}
+
+func windowField(p taskmanager.TaskProcess, field func(*hypr.HyprlandMeta) string) string {
+	if p.Meta == nil || p.Meta.Hyprland == nil {
+		return "-"
+	}
+	return field(p.Meta.Hyprland)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+func formatElapsed(start time.Time) string {
+	if start.IsZero() {
+		return "-"
+	}
+	d := time.Since(start)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d-%02d:%02d:%02d", days, hours, minutes, seconds)
+	case hours > 0:
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+	default:
+		return fmt.Sprintf("%02d:%02d", minutes, seconds)
+	}
+}
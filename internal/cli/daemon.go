@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/daemon"
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/hooks"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/notify"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/watchdog"
+)
+
+func runDaemon(env *env, fs *flag.FlagSet, args []string) error {
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+
+	logPath := filepath.Join(config.StateDir(), "daemon.log")
+	if err := logger.InitFile(logPath); err != nil {
+		return fmt.Errorf("could not open the daemon log: %w", err)
+	}
+
+	socket := daemon.SocketPath()
+	l, err := daemon.Listen(socket)
+	if err != nil {
+		return err
+	}
+	tm, err := taskmanager.NewTaskManager(env.cfg.PollInterval.Duration, env.cfg.SampleWindow.Duration, nil, nil, nil)
+	if err != nil {
+		l.Close()
+		return fmt.Errorf("could not start the task manager: %w", err)
+	}
+	notifier, err := notify.Start(env.cfg.Notifications, tm)
+	if err != nil {
+		fmt.Fprintf(env.stderr, "hyprtask daemon: desktop notifications are off: %v\n", err)
+	}
+	defer notifier.Close()
+	if err := watchdog.Start(env.cfg.Watchdog, tm, notifier); err != nil {
+		l.Close()
+		return err
+	}
+	if err := hooks.Start(env.cfg.Hooks, tm); err != nil {
+		l.Close()
+		return err
+	}
+	server := daemon.NewServer(tm, history.NewStore(history.DefaultCapacity))
+	go tm.Start()
+
+	// closing the listener removes the socket, so clients fall back to polling themselves
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-stop
+		l.Close()
+	}()
+
+	fmt.Fprintf(env.stderr, "hyprtask daemon: listening on %s, polling every %s, logging to %s\n", socket, env.cfg.PollInterval, logPath)
+	logger.Log.Info("daemon started", "socket", socket, "poll_interval", env.cfg.PollInterval)
+	return server.Serve(l)
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/exporter"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+func runExporter(env *env, fs *flag.FlagSet, args []string) error {
+	listen := fs.String("listen", "127.0.0.1:9839", "`address` to serve /metrics on")
+	groupBy := fs.String("group-by", "class", "group the processes of each workspace by `program, class, user or unit`")
+	top := fs.Int("top", 10, "give the `n` busiest groups their own series and sum the rest into \"other\", 0 for all")
+	interval := fs.Duration("interval", env.cfg.PollInterval.Duration, "`interval` between updates")
+	sampleWindow := fs.Duration("sample-window", env.cfg.SampleWindow.Duration, "how long CPU and I/O are measured")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	by, err := viewmodel.ParseGroupBy(*groupBy)
+	if err != nil || by == viewmodel.GroupByNone {
+		return usageErrorf("unknown grouping %q, expected program, class, user or unit", *groupBy)
+	}
+	if *top < 0 {
+		return usageErrorf("--top cannot be negative")
+	}
+
+	s, err := startSession(*interval, fitSampleWindow(fs, *sampleWindow, *interval), viewmodel.ViewOptions{}, env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	exp := exporter.NewExporter(exporter.Options{GroupBy: by, Top: *top})
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exp)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(l)
+	}()
+	fmt.Fprintf(env.stderr, "hyprtask exporter: serving http://%s/metrics\n", l.Addr())
+
+	for {
+		data, err := s.next(true)
+		select {
+		case err := <-serveErr:
+			return err
+		default:
+		}
+		if err != nil {
+			// keep serving the last data, the daemon may be restarting
+			fmt.Fprintf(env.stderr, "hyprtask exporter: %v\n", err)
+			continue
+		}
+		exp.Update(data)
+	}
+}
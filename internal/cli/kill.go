@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/notify"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+	"golang.org/x/sys/unix"
+)
+
+func runKill(env *env, fs *flag.FlagSet, args []string) error {
+	var sel selector
+	signalName := fs.String("signal", "TERM", "`signal` to send, by name (TERM, SIGKILL, hup) or number")
+	dryRun := fs.Bool("dry-run", false, "list the processes that would be signalled, without signalling them")
+	notifyFailure := fs.Bool("notify", false, "also report failures as a desktop notification, for key bindings")
+	sel.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	signal, err := taskmanager.ParseSignal(*signalName)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	pids := make([]int, 0, fs.NArg())
+	for _, arg := range fs.Args() {
+		pid, err := strconv.Atoi(arg)
+		if err != nil || pid <= 0 {
+			return usageErrorf("%q is not a PID", arg)
+		}
+		pids = append(pids, pid)
+	}
+	if len(pids) == 0 && sel.empty() {
+		return usageErrorf("name processes by PID or select them with flags")
+	}
+
+	if sel.needsHyprland() && !hypr.NewHyprlandClient().Available() {
+		return errNoHyprland
+	}
+	s, err := startSession(env.cfg.PollInterval.Duration, env.cfg.SampleWindow.Duration, viewmodel.ViewOptions{}, env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+	// the quick snapshot names every process, no need to wait for CPU samples
+	data, err := s.next(false)
+	if err != nil {
+		return err
+	}
+	byPID := make(map[int]taskmanager.TaskProcess, len(data.All))
+	for _, p := range data.All {
+		byPID[p.PID] = p
+	}
+	// the snapshot of a daemon is up to a poll interval old, and misses
+	// processes started since
+	for _, pid := range pids {
+		if _, ok := byPID[pid]; !ok {
+			if name, err := commName(pid); err == nil {
+				byPID[pid] = taskmanager.TaskProcess{PID: pid, ProgramName: name}
+			}
+		}
+	}
+	if !sel.empty() {
+		// our own command line, and that of the shell that ran us, contain
+		// the --match text
+		self := ancestors(byPID, os.Getpid())
+		for _, p := range sel.filter(data.All) {
+			if !self[p.PID] {
+				pids = append(pids, p.PID)
+			}
+		}
+	}
+	pids = dedup(pids)
+	if len(pids) == 0 {
+		return fmt.Errorf("no process matches")
+	}
+
+	name := unix.SignalName(signal)
+	if *dryRun {
+		for _, pid := range pids {
+			if _, ok := byPID[pid]; !ok {
+				fmt.Fprintf(env.stdout, "would send %s to %d (not running)\n", name, pid)
+				continue
+			}
+			fmt.Fprintf(env.stdout, "would send %s to %d%s\n", name, pid, programName(byPID, pid))
+		}
+		return nil
+	}
+
+	result := s.do(taskmanager.TaskAction{
+		Type:    taskmanager.TaskActionSignal,
+		Payload: taskmanager.SignalPayload{PIDs: pids, Signal: signal},
+	})
+	var failures []string
+	for _, item := range result.Items {
+		if item.Err != nil {
+			failure := fmt.Sprintf("could not send %s to %d%s: %v", name, item.PID, programName(byPID, item.PID), item.Err)
+			fmt.Fprintln(env.stderr, failure)
+			failures = append(failures, failure)
+			continue
+		}
+		fmt.Fprintf(env.stdout, "sent %s to %d%s\n", name, item.PID, programName(byPID, item.PID))
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	err = fmt.Errorf("%d of %d processes could not be signalled", len(failures), len(result.Items))
+	if *notifyFailure {
+		notifyKillFailure(env, err, failures)
+	}
+	return err
+}
+
+// notifyKillFailure shows the failures of kill on the desktop, where a key
+// binding's stderr would not
+func notifyKillFailure(env *env, err error, failures []string) {
+	// the command exits right away, so there is no window button to listen for
+	notifier, connectErr := notify.Connect(env.cfg.Notifications.Dedupe.Duration, nil)
+	if connectErr != nil {
+		fmt.Fprintf(env.stderr, "hyprtask kill: %v\n", connectErr)
+		return
+	}
+	defer notifier.Close()
+	notifyErr := notifier.Notify(notify.Notification{
+		Summary: "hyprtask kill: " + err.Error(),
+		Body:    strings.Join(failures, "\n"),
+		Urgency: notify.UrgencyCritical,
+	})
+	if notifyErr != nil {
+		fmt.Fprintf(env.stderr, "hyprtask kill: %v\n", notifyErr)
+	}
+}
+
+// ancestors returns pid and the processes it descends from
+func ancestors(byPID map[int]taskmanager.TaskProcess, pid int) map[int]bool {
+	chain := map[int]bool{pid: true}
+	for p, ok := byPID[pid]; ok && !chain[p.PPID]; p, ok = byPID[p.PPID] {
+		chain[p.PPID] = true
+	}
+	return chain
+}
+
+func dedup(pids []int) []int {
+	seen := make(map[int]bool, len(pids))
+	unique := pids[:0]
+	for _, pid := range pids {
+		if !seen[pid] {
+			seen[pid] = true
+			unique = append(unique, pid)
+		}
+	}
+	return unique
+}
+
+// programName returns " (name)" of pid, or nothing when it is unknown
+func programName(byPID map[int]taskmanager.TaskProcess, pid int) string {
+	if p, ok := byPID[pid]; ok {
+		return " (" + p.ProgramName + ")"
+	}
+	return ""
+}
+
+// commName reads the program name of pid from /proc, as the snapshots name it
+func commName(pid int) (string, error) {
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(comm)), nil
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"flag"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+)
+
+// listFlags are the flags of the commands that print a process table
+type listFlags struct {
+	sort         string
+	columns      string
+	noHeader     bool
+	limit        int
+	sampleWindow time.Duration
+}
+
+func (l *listFlags) register(fs *flag.FlagSet, env *env, sort, columns string, limit int) {
+	fs.StringVar(&l.sort, "sort", sort, sortUsage())
+	fs.StringVar(&l.columns, "columns", columns, "comma separated `ids` of the columns to print: "+columnIDs())
+	fs.BoolVar(&l.noHeader, "no-header", false, "leave out the header line")
+	fs.IntVar(&l.limit, "limit", limit, "print at most `n` processes, 0 for all")
+	fs.DurationVar(&l.sampleWindow, "sample-window", env.cfg.SampleWindow.Duration, "how long CPU and I/O are measured")
+}
+
+func runPS(env *env, fs *flag.FlagSet, args []string) error {
+	var list listFlags
+	var sel selector
+	list.register(fs, env, "pid:asc", defaultPSColumns, 0)
+	sel.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+
+	cols, err := parseColumns(list.columns)
+	if err != nil {
+		return err
+	}
+	opts, err := parseSort(list.sort)
+	if err != nil {
+		return err
+	}
+	if sel.needsHyprland() && !hypr.NewHyprlandClient().Available() {
+		return errNoHyprland
+	}
+	s, err := startSession(list.sampleWindow+sessionSlack, list.sampleWindow, opts, env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+	data, err := s.next(true)
+	if err != nil {
+		return err
+	}
+
+	procs := sel.filter(data.All)
+	if list.limit > 0 && len(procs) > list.limit {
+		procs = procs[:list.limit]
+	}
+	return writeTable(env.stdout, procs, cols, !list.noHeader)
+}
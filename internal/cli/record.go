@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/daemon"
+	"github.com/paulvinueza30/hyprtask/internal/recording"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+func runRecord(env *env, fs *flag.FlagSet, args []string) error {
+	output := fs.String("o", "", "`file` to write, default hyprtask-<date>-<time>.rec.gz")
+	duration := fs.Duration("duration", 0, "stop after `duration`, 0 to record until interrupted")
+	interval := fs.Duration("interval", env.cfg.PollInterval.Duration, "`interval` between snapshots, unless the daemon polls")
+	sampleWindow := fs.Duration("sample-window", env.cfg.SampleWindow.Duration, "how long CPU and I/O are measured")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if *duration < 0 {
+		return usageErrorf("--duration cannot be negative")
+	}
+	if *output == "" {
+		*output = time.Now().Format("hyprtask-20060102-150405.rec.gz")
+	}
+
+	snapshots, err := subscribe(*interval, fitSampleWindow(fs, *sampleWindow, *interval), env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+	rec, err := recording.Create(*output)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	var timeout <-chan time.Time
+	if *duration > 0 {
+		timeout = time.After(*duration)
+	}
+	fmt.Fprintf(env.stderr, "hyprtask record: recording to %s, stop with ctrl+c\n", *output)
+
+	var recordErr error
+loop:
+	for {
+		select {
+		case snapshot := <-snapshots:
+			if recordErr = rec.Record(snapshot); recordErr != nil {
+				break loop
+			}
+		case <-stop:
+			break loop
+		case <-timeout:
+			break loop
+		}
+	}
+	if err := rec.Close(); err != nil && recordErr == nil {
+		recordErr = err
+	}
+	if recordErr != nil {
+		return recordErr
+	}
+	fmt.Fprintf(env.stderr, "hyprtask record: wrote %d snapshots to %s\n", rec.Frames(), *output)
+	return nil
+}
+
+// subscribe returns the snapshots of the daemon when useDaemon is set and one
+// runs, else of a task manager polling here
+func subscribe(pollInterval, sampleWindow time.Duration, useDaemon bool) (<-chan taskmanager.Snapshot, error) {
+	if useDaemon {
+		if client, err := daemon.Dial(daemon.SocketPath()); err == nil {
+			snapshots := make(chan taskmanager.Snapshot, 3)
+			go func() {
+				for {
+					client.Subscribe(snapshots)
+					time.Sleep(time.Second)
+				}
+			}()
+			return snapshots, nil
+		}
+	}
+	if sampleWindow >= pollInterval {
+		return nil, usageErrorf("the sample window (%s) must be shorter than the interval (%s)", sampleWindow, pollInterval)
+	}
+	tm, err := taskmanager.NewTaskManager(pollInterval, sampleWindow, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not start the task manager: %w", err)
+	}
+	snapshots, _ := tm.Subscribe()
+	go tm.Start()
+	return snapshots, nil
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+// selector picks processes by the flags ps and kill share. Every criterion
+// that is set has to match.
+type selector struct {
+	match     string
+	user      string
+	workspace string
+	class     string
+	windows   bool
+}
+
+func (s *selector) register(fs *flag.FlagSet) {
+	fs.StringVar(&s.match, "match", "", "only processes whose name or command line contains `text` (ignoring case)")
+	fs.StringVar(&s.user, "user", "", "only processes of `user`")
+	fs.StringVar(&s.workspace, "workspace", "", "only windows on the workspace with this `name or ID`")
+	fs.StringVar(&s.class, "class", "", "only windows of this `class` (ignoring case)")
+	fs.BoolVar(&s.windows, "windows", false, "only processes with a Hyprland window")
+}
+
+// empty reports whether no criterion is set, so every process matches
+func (s selector) empty() bool {
+	return s == selector{}
+}
+
+// needsHyprland reports whether a criterion looks at windows
+func (s selector) needsHyprland() bool {
+	return s.workspace != "" || s.class != "" || s.windows
+}
+
+func (s selector) matches(p taskmanager.TaskProcess) bool {
+	if s.match != "" {
+		text := strings.ToLower(s.match)
+		if !strings.Contains(strings.ToLower(p.ProgramName), text) && !strings.Contains(strings.ToLower(p.CommandLine), text) {
+			return false
+		}
+	}
+	if s.user != "" && p.User != s.user {
+		return false
+	}
+	if !s.needsHyprland() {
+		return true
+	}
+
+	if p.Meta == nil || p.Meta.Hyprland == nil {
+		return false
+	}
+	w := p.Meta.Hyprland
+	if s.workspace != "" && w.Workspace.Name != s.workspace && strconv.Itoa(w.Workspace.ID) != s.workspace {
+		return false
+	}
+	return s.class == "" || strings.EqualFold(w.Class, s.class)
+}
+
+// matchesWorkspace reports whether ws is the workspace asked for, if any
+func (s selector) matchesWorkspace(ws *viewmodel.WorkspaceData) bool {
+	return s.workspace == "" || ws.WorkspaceName == s.workspace || strconv.Itoa(ws.WorkspaceID) == s.workspace
+}
+
+func (s selector) filter(procs []taskmanager.TaskProcess) []taskmanager.TaskProcess {
+	if s.empty() {
+		return procs
+	}
+	var picked []taskmanager.TaskProcess
+	for _, p := range procs {
+		if s.matches(p) {
+			picked = append(picked, p)
+		}
+	}
+	return picked
+}
+
+// parseSort reads "key[:order][,key[:order]...]" into view options. The first
+// key sorts, the others break ties. A key without an order sorts descending,
+// like --sort of the UI.
+func parseSort(spec string) (viewmodel.ViewOptions, error) {
+	var opts viewmodel.ViewOptions
+	for i, part := range strings.Split(spec, ",") {
+		key, order, _ := strings.Cut(strings.TrimSpace(part), ":")
+		if order == "" {
+			order = "none"
+		}
+		parsed, err := viewmodel.ParseViewOptions(key, order)
+		if err != nil {
+			return viewmodel.ViewOptions{}, usageError{msg: err.Error()}
+		}
+		if i == 0 {
+			opts = parsed
+			continue
+		}
+		if len(opts.ThenBy) == viewmodel.MaxThenBy {
+			return viewmodel.ViewOptions{}, usageErrorf("at most %d sort keys can follow the first one", viewmodel.MaxThenBy)
+		}
+		opts.ThenBy = append(opts.ThenBy, viewmodel.SortOption{Key: parsed.SortKey, Order: parsed.SortOrder})
+	}
+	return opts, nil
+}
+
+// sortUsage documents the --sort flag of ps and top
+func sortUsage() string {
+	return "sort by `key[:order]`, with up to " + strconv.Itoa(viewmodel.MaxThenBy) +
+		" comma separated tie-breakers, e.g. workspace:asc,cpu:desc. Keys: " + strings.Join(viewmodel.SortKeyNames(), ", ")
+}
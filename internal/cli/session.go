@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/daemon"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+// sessionSlack is how long past the sampling window a session waits for data
+const sessionSlack = 5 * time.Second
+
+var errNoData = errors.New("timed out waiting for process data")
+
+// session runs the task manager and view model the way the UI does, without the UI
+type session struct {
+	displayData chan viewmodel.DisplayData
+	actions     chan taskmanager.TaskAction
+	results     chan taskmanager.ActionResult
+	timeout     time.Duration
+}
+
+// startSession polls every pollInterval, sampling CPU and I/O over sampleWindow,
+// and sorts the processes by opts. With useDaemon it reads from the daemon
+// when one runs, at the daemon's pace.
+func startSession(pollInterval, sampleWindow time.Duration, opts viewmodel.ViewOptions, useDaemon bool) (*session, error) {
+	if sampleWindow >= pollInterval {
+		return nil, usageErrorf("the sample window (%s) must be shorter than the interval (%s)", sampleWindow, pollInterval)
+	}
+	snapshots := make(chan taskmanager.Snapshot, 3)
+	s := &session{
+		displayData: make(chan viewmodel.DisplayData, 1),
+		actions:     make(chan taskmanager.TaskAction, 1),
+		results:     make(chan taskmanager.ActionResult, 1),
+		timeout:     pollInterval + sampleWindow + sessionSlack,
+	}
+	vm := viewmodel.NewViewModel(snapshots, make(chan viewmodel.ViewAction), s.displayData, opts)
+	go vm.Start()
+	if useDaemon {
+		if client, err := daemon.Dial(daemon.SocketPath()); err == nil {
+			go client.Serve(snapshots, s.actions, s.results)
+			return s, nil
+		}
+	}
+	tm, err := taskmanager.NewTaskManager(pollInterval, sampleWindow, snapshots, s.actions, s.results)
+	if err != nil {
+		return nil, fmt.Errorf("could not start the task manager: %w", err)
+	}
+	go tm.Start()
+	return s, nil
+}
+
+// fitSampleWindow shortens the configured sample window to fit interval, so
+// that a short interval alone does not need a matching --sample-window. A
+// window given on the command line is kept, and rejected by startSession.
+func fitSampleWindow(fs *flag.FlagSet, window, interval time.Duration) time.Duration {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		set = set || f.Name == "sample-window"
+	})
+	if set || window < interval {
+		return window
+	}
+	return interval * 4 / 5
+}
+
+// next waits for the next display data. With accurate set it skips the quick
+// snapshots, whose CPU and I/O values are placeholders.
+func (s *session) next(accurate bool) (viewmodel.DisplayData, error) {
+	deadline := time.After(s.timeout)
+	for {
+		select {
+		case data := <-s.displayData:
+			if data.Accurate || !accurate {
+				return data, nil
+			}
+		case <-deadline:
+			return viewmodel.DisplayData{}, errNoData
+		}
+	}
+}
+
+// do runs action and waits for its result
+func (s *session) do(action taskmanager.TaskAction) taskmanager.ActionResult {
+	s.actions <- action
+	return <-s.results
+}
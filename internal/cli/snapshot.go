@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+
+	"github.com/paulvinueza30/hyprtask/schema"
+)
+
+func runSnapshot(env *env, fs *flag.FlagSet, args []string) error {
+	stream := fs.Bool("stream", false, "write one document per line after every poll, until interrupted (NDJSON)")
+	interval := fs.Duration("interval", env.cfg.PollInterval.Duration, "`interval` between documents with --stream")
+	sampleWindow := fs.Duration("sample-window", env.cfg.SampleWindow.Duration, "how long CPU and I/O are measured")
+	sort := fs.String("sort", "pid:asc", sortUsage())
+	pretty := fs.Bool("pretty", false, "indent the document, not for --stream")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if *stream && *pretty {
+		return usageErrorf("--pretty would break the one document per line of --stream")
+	}
+	if !*stream {
+		*interval = *sampleWindow + sessionSlack
+	}
+
+	opts, err := parseSort(*sort)
+	if err != nil {
+		return err
+	}
+	s, err := startSession(*interval, *sampleWindow, opts, env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(env.stdout)
+	if *pretty {
+		enc.SetIndent("", "  ")
+	}
+	for {
+		data, err := s.next(true)
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(schema.NewDocument(data)); err != nil {
+			return err
+		}
+		if !*stream {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+// frames is what top and the batch mode print, one frame per update
+type frames struct {
+	iterations   int // 0 to run until interrupted
+	interval     time.Duration
+	sampleWindow time.Duration
+	sort         viewmodel.ViewOptions
+	sel          selector
+	cols         []column
+	limit        int
+	header       bool
+}
+
+func runTop(env *env, fs *flag.FlagSet, args []string) error {
+	var list listFlags
+	var f frames
+	fs.IntVar(&f.iterations, "n", 0, "stop after `n` updates, 0 to run until interrupted")
+	fs.DurationVar(&f.interval, "d", env.cfg.PollInterval.Duration, "`interval` between updates")
+	list.register(fs, env, "cpu:desc", defaultTopColumns, 20)
+	f.sel.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	f.sampleWindow = fitSampleWindow(fs, list.sampleWindow, f.interval)
+	f.limit, f.header = list.limit, !list.noHeader
+
+	var err error
+	if f.cols, err = parseColumns(list.columns); err != nil {
+		return err
+	}
+	if f.sort, err = parseSort(list.sort); err != nil {
+		return err
+	}
+	return f.print(env)
+}
+
+// print writes the frames to stdout
+func (f frames) print(env *env) error {
+	if f.iterations < 0 {
+		return usageErrorf("the number of updates cannot be negative")
+	}
+	if f.sel.needsHyprland() && !hypr.NewHyprlandClient().Available() {
+		return errNoHyprland
+	}
+	s, err := startSession(f.interval, f.sampleWindow, f.sort, env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; f.iterations == 0 || i < f.iterations; i++ {
+		data, err := s.next(true)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(env.stdout)
+		}
+		if err := f.write(env.stdout, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write prints one frame: the system summary, the busiest processes and the
+// workspace totals
+func (f frames) write(w io.Writer, data viewmodel.DisplayData) error {
+	writeSummary(w, data)
+	procs := f.sel.filter(data.All)
+	if f.limit > 0 && len(procs) > f.limit {
+		procs = procs[:f.limit]
+	}
+	if err := writeTable(w, procs, f.cols, f.header); err != nil {
+		return err
+	}
+
+	var workspaces []*viewmodel.WorkspaceData
+	for _, ws := range data.Hypr.Workspaces {
+		if f.sel.matchesWorkspace(ws) {
+			workspaces = append(workspaces, ws)
+		}
+	}
+	if len(workspaces) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w)
+	return writeWorkspaces(w, workspaces, f.header)
+}
+
+// writeSummary prints the system lines above the process table, like top
+func writeSummary(w io.Writer, data viewmodel.DisplayData) {
+	sys := data.System
+	fmt.Fprintf(w, "hyprtask - %s up %s, load average: %.2f %.2f %.2f\n",
+		data.Timestamp.Local().Format(time.DateTime), formatUptime(sys.Uptime), sys.Load1, sys.Load5, sys.Load15)
+	fmt.Fprintf(w, "CPU: %s%%  Mem: %s%% (%s/%s)  Swap: %s%% (%s/%s)\n",
+		formatPercent(sys.CPU), formatPercent(sys.MEM), formatBytes(sys.MemUsed), formatBytes(sys.MemTotal),
+		formatPercent(sys.SWAP), formatBytes(sys.SwapUsed), formatBytes(sys.SwapTotal))
+	fmt.Fprintf(w, "Processes: %d, workspaces: %d\n\n", len(data.All), data.Hypr.WorkspaceCount)
+}
+
+func formatUptime(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
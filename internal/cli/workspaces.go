@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+var errNoHyprland = errors.New("Hyprland is not running (HYPRLAND_INSTANCE_SIGNATURE is not set)")
+
+func runWorkspaces(env *env, fs *flag.FlagSet, args []string) error {
+	noHeader := fs.Bool("no-header", false, "leave out the header line")
+	sampleWindow := fs.Duration("sample-window", env.cfg.SampleWindow.Duration, "how long CPU and I/O are measured")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	if !hypr.NewHyprlandClient().Available() {
+		return errNoHyprland
+	}
+
+	s, err := startSession(*sampleWindow+sessionSlack, *sampleWindow, viewmodel.ViewOptions{}, env.cfg.UseDaemon)
+	if err != nil {
+		return err
+	}
+	data, err := s.next(true)
+	if err != nil {
+		return err
+	}
+
+	return writeWorkspaces(env.stdout, data.Hypr.Workspaces, !*noHeader)
+}
+
+// writeWorkspaces prints a table of workspaces with their window count and load
+func writeWorkspaces(w io.Writer, workspaces []*viewmodel.WorkspaceData, header bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if header {
+		fmt.Fprintln(tw, "ID\tNAME\tWINDOWS\tCPU%\tMEM%\tCLASSES")
+	}
+	for _, ws := range workspaces {
+		var classes []string
+		for _, p := range ws.ActiveProcs {
+			if class := p.Meta.Hyprland.Class; class != "" && !slices.Contains(classes, class) {
+				classes = append(classes, class)
+			}
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", ws.WorkspaceID, cleanCell(ws.WorkspaceName), ws.ActiveProcsCount,
+			formatPercent(ws.TotalCPU), formatPercent(ws.TotalMEM), cleanCell(strings.Join(classes, ",")))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,485 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	appDir   = "hyprtask"
+	fileName = "config.toml"
+)
+
+// Screen names accepted by default_screen
+const (
+	ScreenWorkspaces = "workspaces"
+	ScreenProcesses  = "processes"
+)
+
+type Config struct {
+	PollInterval  Duration            `toml:"poll_interval"`
+	SampleWindow  Duration            `toml:"sample_window"` // CPU/IO sampling window, must be shorter than poll_interval
+	DefaultScreen string              `toml:"default_screen"`
+	Columns       []string            `toml:"columns"` // process list column IDs, in display order. Empty = defaults
+	Sort          SortConfig          `toml:"sort"`
+	Theme         ThemeConfig         `toml:"theme"`
+	KeyPreset     string              `toml:"key_preset"`
+	Keys          map[string][]string `toml:"keys"`       // action name -> keys, replaces the preset's keys of that action
+	UseDaemon     bool                `toml:"use_daemon"` // read from "hyprtask daemon" when it runs instead of polling
+	Watchdog      WatchdogConfig      `toml:"watchdog"`
+	Notifications NotificationsConfig `toml:"notifications"`
+	Hooks         HooksConfig         `toml:"hooks"`
+
+	Path string `toml:"-"` // file the config was loaded from, and where choices are saved back
+}
+
+type SortConfig struct {
+	Key   string `toml:"key"`
+	Order string `toml:"order"`
+}
+
+type ThemeConfig struct {
+	Name   string       `toml:"name"` // built-in theme, "hyprland", a theme file path, or a file name in ThemesDir
+	Colors ColorsConfig `toml:"colors"`
+}
+
+// ColorsConfig overrides individual palette colors. Empty values keep the theme's color.
+type ColorsConfig struct {
+	Background string `toml:"background"`
+	Foreground string `toml:"foreground"`
+	Muted      string `toml:"muted"`
+	Border     string `toml:"border"`
+	Accent     string `toml:"accent"`
+	Warning    string `toml:"warning"`
+	Success    string `toml:"success"`
+	Error      string `toml:"error"`
+	Highlight  string `toml:"highlight"`
+}
+
+// WatchdogConfig holds the rules that act on busy processes and workspaces
+// without anyone watching
+type WatchdogConfig struct {
+	DryRun   bool         `toml:"dry_run"`   // only log what the rules would do
+	AuditLog string       `toml:"audit_log"` // JSON lines file every triggered rule is appended to
+	Rules    []RuleConfig `toml:"rules"`
+}
+
+// NotificationsConfig controls the desktop notifications of the daemon, or of
+// the UI when it polls for itself
+type NotificationsConfig struct {
+	Enabled bool     `toml:"enabled"`
+	Watch   []string `toml:"watch"`  // program names or window classes whose exits are notified
+	Dedupe  Duration `toml:"dedupe"` // a notification repeated within it is dropped
+}
+
+// HooksConfig runs commands on events, see HookConfig
+type HooksConfig struct {
+	Timeout       Duration     `toml:"timeout"`        // a command running longer is killed
+	MaxConcurrent int          `toml:"max_concurrent"` // commands running at once, the others wait
+	Log           string       `toml:"log"`            // file the output of every command is appended to
+	On            []HookConfig `toml:"on"`
+}
+
+// HookEventWorkspaceLoad is the hook event of a workspace getting busy. The
+// other events are owned by the task manager.
+const HookEventWorkspaceLoad = "workspace_load"
+
+// HookConfig runs command with sh -c on an event, which it reads as JSON on
+// stdin and in HYPRTASK_* environment variables. The filters narrow the
+// processes, windows and workspaces it runs for; every filter that is set has
+// to match.
+type HookConfig struct {
+	Event     string  `toml:"event"`
+	Command   string  `toml:"command"`
+	Program   string  `toml:"program"`   // program name
+	Class     string  `toml:"class"`     // window class, ignoring case
+	Workspace string  `toml:"workspace"` // workspace name or ID
+	CPUAbove  float64 `toml:"cpu_above"` // workspace_load: total CPU percentage that trips it
+	MemAbove  float64 `toml:"mem_above"` // workspace_load: total memory percentage that trips it
+}
+
+// Rule scopes, metrics and actions
+const (
+	RuleScopeProcess   = "process"
+	RuleScopeWorkspace = "workspace"
+
+	RuleMetricCPU = "cpu"
+	RuleMetricMEM = "mem"
+
+	RuleActionSignal       = "signal"
+	RuleActionNotify       = "notify"
+	RuleActionCloseWindows = "close_windows"
+)
+
+// RuleConfig triggers an action once a metric of a process, or the sum over a
+// workspace, has stayed above a threshold for a while. The filters narrow
+// which processes are looked at; every filter that is set has to match.
+type RuleConfig struct {
+	Name       string   `toml:"name"`
+	Scope      string   `toml:"scope"`       // process (default) or workspace
+	Class      string   `toml:"class"`       // window class, ignoring case
+	Program    string   `toml:"program"`     // program name
+	User       string   `toml:"user"`        // owner of the process
+	Workspace  string   `toml:"workspace"`   // workspace name or ID
+	Metric     string   `toml:"metric"`      // cpu or mem, in percent
+	Above      float64  `toml:"above"`       // threshold that trips the rule
+	ClearBelow float64  `toml:"clear_below"` // the rule resets below it, 0 for above
+	For        Duration `toml:"for"`         // how long the metric has to stay above
+	Action     string   `toml:"action"`      // signal, notify or close_windows
+	Signal     string   `toml:"signal"`      // for the signal action, e.g. "STOP" or "TERM"
+	Cooldown   Duration `toml:"cooldown"`    // least time between two actions on the same process or workspace
+	DryRun     bool     `toml:"dry_run"`     // only log what this rule would do
+}
+
+// Duration is a time.Duration written as a string such as "5s" or "1m30s"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q, expected e.g. \"5s\" or \"1m\"", string(text))
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func Default() Config {
+	return Config{
+		PollInterval:  Duration{5 * time.Second},
+		SampleWindow:  Duration{4 * time.Second},
+		DefaultScreen: ScreenWorkspaces,
+		Sort:          SortConfig{Key: "none", Order: "none"},
+		KeyPreset:     "default",
+		UseDaemon:     true,
+		Watchdog:      WatchdogConfig{AuditLog: filepath.Join(StateDir(), "watchdog.log")},
+		Notifications: NotificationsConfig{Enabled: true, Dedupe: Duration{time.Minute}},
+		Hooks:         HooksConfig{Timeout: Duration{10 * time.Second}, MaxConcurrent: 4, Log: filepath.Join(StateDir(), "hooks.log")},
+		Path:          Path(),
+	}
+}
+
+// Path returns $XDG_CONFIG_HOME/hyprtask/config.toml, falling back to ~/.config
+func Path() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config")
+		}
+	}
+	return filepath.Join(dir, appDir, fileName)
+}
+
+// StateDir returns $XDG_STATE_HOME/hyprtask, falling back to ~/.local/state
+func StateDir() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".local", "state")
+		}
+	}
+	return filepath.Join(dir, appDir)
+}
+
+// ThemesDir is the directory searched for theme files, next to the config file
+func (c Config) ThemesDir() string {
+	return filepath.Join(filepath.Dir(c.Path), "themes")
+}
+
+// Load reads the config file at path, or at Path() when path is empty.
+// A missing file at the default location is not an error and yields the defaults.
+func Load(path string) (Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = Path()
+	}
+	cfg := Default()
+	cfg.Path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	meta, err := toml.Decode(string(data), &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = key.String()
+		}
+		return cfg, fmt.Errorf("%s: unknown keys: %s", path, strings.Join(keys, ", "))
+	}
+	return cfg, nil
+}
+
+// Choices lists the values some settings are validated against. They are
+// owned by other packages and passed in to keep this package dependency free.
+type Choices struct {
+	Columns         []string
+	SortableColumns []string // at least one visible column has to be sortable
+	Actions         []string
+	KeyPresets      []string
+	SortKeys        []string
+	Themes          []string // only listed in the starter config, theme names are resolved by the UI
+	HookEvents      []string
+	ParseSignal     func(name string) (syscall.Signal, error) // for the signal of watchdog rules
+}
+
+// Validate checks every setting and reports all problems at once
+func (c Config) Validate(choices Choices) error {
+	var errs []error
+	if c.PollInterval.Duration < 500*time.Millisecond {
+		errs = append(errs, fmt.Errorf("poll_interval must be at least 500ms, got %s", c.PollInterval))
+	}
+	if c.SampleWindow.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("sample_window must be positive, got %s", c.SampleWindow))
+	} else if c.SampleWindow.Duration >= c.PollInterval.Duration {
+		errs = append(errs, fmt.Errorf("sample_window (%s) must be shorter than poll_interval (%s)", c.SampleWindow, c.PollInterval))
+	}
+	if c.DefaultScreen != ScreenWorkspaces && c.DefaultScreen != ScreenProcesses {
+		errs = append(errs, fmt.Errorf("default_screen must be %q or %q, got %q", ScreenWorkspaces, ScreenProcesses, c.DefaultScreen))
+	}
+	for _, col := range c.Columns {
+		if !slices.Contains(choices.Columns, col) {
+			errs = append(errs, fmt.Errorf("columns: unknown column %q (valid: %s)", col, strings.Join(choices.Columns, ", ")))
+		}
+	}
+	if len(c.Columns) > 0 && !slices.ContainsFunc(c.Columns, func(col string) bool { return slices.Contains(choices.SortableColumns, col) }) {
+		errs = append(errs, fmt.Errorf("columns: at least one column has to be sortable (sortable: %s)", strings.Join(choices.SortableColumns, ", ")))
+	}
+	if !slices.Contains(choices.SortKeys, c.Sort.Key) {
+		errs = append(errs, fmt.Errorf("sort.key: unknown key %q (valid: %s)", c.Sort.Key, strings.Join(choices.SortKeys, ", ")))
+	}
+	if !slices.Contains([]string{"asc", "desc", "none"}, c.Sort.Order) {
+		errs = append(errs, fmt.Errorf("sort.order must be asc, desc or none, got %q", c.Sort.Order))
+	}
+	for name, color := range c.Theme.Colors.byName() {
+		if color != "" && !validColor(color) {
+			errs = append(errs, fmt.Errorf("theme.colors.%s: %q is not a hex color (#rrggbb) or ANSI color number (0-255)", name, color))
+		}
+	}
+	if !slices.Contains(choices.KeyPresets, c.KeyPreset) {
+		errs = append(errs, fmt.Errorf("key_preset: unknown preset %q (valid: %s)", c.KeyPreset, strings.Join(choices.KeyPresets, ", ")))
+	}
+	for action, keys := range c.Keys {
+		if !slices.Contains(choices.Actions, action) {
+			errs = append(errs, fmt.Errorf("keys: unknown action %q (valid: %s)", action, strings.Join(choices.Actions, ", ")))
+		}
+		if len(keys) == 0 {
+			errs = append(errs, fmt.Errorf("keys.%s: needs at least one key", action))
+		}
+	}
+	errs = append(errs, c.Watchdog.validate(choices.ParseSignal)...)
+	errs = append(errs, c.Hooks.validate(choices.HookEvents)...)
+	if c.Notifications.Dedupe.Duration < 0 {
+		errs = append(errs, fmt.Errorf("notifications.dedupe cannot be negative, got %s", c.Notifications.Dedupe))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config %s:\n%w", c.Path, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (h HooksConfig) validate(events []string) []error {
+	var errs []error
+	if h.Timeout.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("hooks.timeout must be positive, got %s", h.Timeout))
+	}
+	if h.MaxConcurrent < 1 {
+		errs = append(errs, fmt.Errorf("hooks.max_concurrent must be at least 1, got %d", h.MaxConcurrent))
+	}
+	if len(h.On) > 0 && h.Log == "" {
+		errs = append(errs, errors.New("hooks.log cannot be empty"))
+	}
+	for i, hook := range h.On {
+		at := fmt.Sprintf("hooks.on[%d]", i)
+		if !slices.Contains(events, hook.Event) {
+			errs = append(errs, fmt.Errorf("%s: unknown event %q (valid: %s)", at, hook.Event, strings.Join(events, ", ")))
+		}
+		if strings.TrimSpace(hook.Command) == "" {
+			errs = append(errs, fmt.Errorf("%s: needs a command", at))
+		}
+		if hook.CPUAbove < 0 || hook.MemAbove < 0 {
+			errs = append(errs, fmt.Errorf("%s: cpu_above and mem_above cannot be negative", at))
+		}
+		switch {
+		case hook.Event == HookEventWorkspaceLoad && hook.CPUAbove == 0 && hook.MemAbove == 0:
+			errs = append(errs, fmt.Errorf("%s: %s needs cpu_above or mem_above", at, HookEventWorkspaceLoad))
+		case hook.Event != HookEventWorkspaceLoad && (hook.CPUAbove != 0 || hook.MemAbove != 0):
+			errs = append(errs, fmt.Errorf("%s: cpu_above and mem_above only apply to %s", at, HookEventWorkspaceLoad))
+		}
+	}
+	return errs
+}
+
+func (w WatchdogConfig) validate(parseSignal func(string) (syscall.Signal, error)) []error {
+	var errs []error
+	if len(w.Rules) > 0 && w.AuditLog == "" {
+		errs = append(errs, errors.New("watchdog.audit_log cannot be empty"))
+	}
+	names := make(map[string]bool)
+	for i, r := range w.Rules {
+		at := fmt.Sprintf("watchdog.rules[%d]", i)
+		if r.Name != "" {
+			at = fmt.Sprintf("watchdog rule %q", r.Name)
+		}
+		switch {
+		case r.Name == "":
+			errs = append(errs, fmt.Errorf("%s: needs a name", at))
+		case names[r.Name]:
+			errs = append(errs, fmt.Errorf("%s: the name is used twice", at))
+		}
+		names[r.Name] = true
+
+		if r.Scope != "" && r.Scope != RuleScopeProcess && r.Scope != RuleScopeWorkspace {
+			errs = append(errs, fmt.Errorf("%s: scope must be %q or %q, got %q", at, RuleScopeProcess, RuleScopeWorkspace, r.Scope))
+		}
+		if r.Metric != RuleMetricCPU && r.Metric != RuleMetricMEM {
+			errs = append(errs, fmt.Errorf("%s: metric must be %q or %q, got %q", at, RuleMetricCPU, RuleMetricMEM, r.Metric))
+		}
+		if r.Above <= 0 {
+			errs = append(errs, fmt.Errorf("%s: above must be positive, got %g", at, r.Above))
+		}
+		if r.ClearBelow < 0 || r.ClearBelow > r.Above {
+			errs = append(errs, fmt.Errorf("%s: clear_below must be between 0 and above (%g), got %g", at, r.Above, r.ClearBelow))
+		}
+		if r.For.Duration < 0 || r.Cooldown.Duration < 0 {
+			errs = append(errs, fmt.Errorf("%s: for and cooldown cannot be negative", at))
+		}
+		switch r.Action {
+		case RuleActionSignal:
+			if r.Signal == "" {
+				errs = append(errs, fmt.Errorf("%s: the signal action needs a signal, e.g. \"STOP\"", at))
+			} else if parseSignal != nil {
+				if _, err := parseSignal(r.Signal); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", at, err))
+				}
+			}
+		case RuleActionNotify, RuleActionCloseWindows:
+			if r.Signal != "" {
+				errs = append(errs, fmt.Errorf("%s: signal only applies to the signal action", at))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s: action must be %s, %s or %s, got %q", at, RuleActionSignal, RuleActionNotify, RuleActionCloseWindows, r.Action))
+		}
+	}
+	return errs
+}
+
+func (c ColorsConfig) byName() map[string]string {
+	return map[string]string{
+		"background": c.Background,
+		"foreground": c.Foreground,
+		"muted":      c.Muted,
+		"border":     c.Border,
+		"accent":     c.Accent,
+		"warning":    c.Warning,
+		"success":    c.Success,
+		"error":      c.Error,
+		"highlight":  c.Highlight,
+	}
+}
+
+func validColor(color string) bool {
+	if strings.HasPrefix(color, "#") {
+		if len(color) != 7 && len(color) != 4 {
+			return false
+		}
+		for _, r := range color[1:] {
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+		return true
+	}
+	var n int
+	if _, err := fmt.Sscanf(color, "%d", &n); err != nil || fmt.Sprint(n) != color {
+		return false
+	}
+	return n >= 0 && n <= 255
+}
+
+// SaveColumns persists the column choice to path. Only the columns key is
+// rewritten so comments and the rest of a hand-edited file survive.
+func SaveColumns(path string, columns []string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	line := "columns = [" + quoteList(columns) + "]"
+
+	content := setTopLevelKey(string(data), "columns", line)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// setTopLevelKey replaces the assignment of key before the first table header,
+// or inserts line there if the key is absent. Multi-line arrays are replaced whole.
+func setTopLevelKey(content, key, line string) string {
+	if strings.TrimSpace(content) == "" {
+		return line + "\n"
+	}
+	lines := strings.Split(content, "\n")
+	insertAt := len(lines)
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "[") {
+			insertAt = i
+			break
+		}
+		name, _, found := strings.Cut(trimmed, "=")
+		if !found || strings.TrimSpace(name) != key {
+			continue
+		}
+		end := i
+		for depth := bracketDepth(lines[i]); depth > 0 && end+1 < len(lines); {
+			end++
+			depth += bracketDepth(lines[end])
+		}
+		out := append([]string{}, lines[:i]...)
+		out = append(out, line)
+		return strings.Join(append(out, lines[end+1:]...), "\n")
+	}
+
+	out := append([]string{}, lines[:insertAt]...)
+	out = append(out, line)
+	if insertAt < len(lines) {
+		out = append(out, "")
+	}
+	result := strings.Join(append(out, lines[insertAt:]...), "\n")
+	if !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+	return result
+}
+
+func bracketDepth(line string) int {
+	if idx := strings.Index(line, "#"); idx >= 0 {
+		line = line[:idx]
+	}
+	return strings.Count(line, "[") - strings.Count(line, "]")
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// WriteDefault writes a commented starter config holding the default values.
+// Column IDs, sort keys and key bindings are owned by other packages and passed in.
+func WriteDefault(w io.Writer, choices Choices, defaultColumns []string, defaultKeys map[string][]string) error {
+	d := Default()
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# hyprtask configuration\n")
+	fmt.Fprintf(&b, "# Save as %s. Every setting is optional.\n\n", Path())
+
+	fmt.Fprintf(&b, "# How often processes and system usage are refreshed\n")
+	fmt.Fprintf(&b, "poll_interval = %q\n\n", d.PollInterval)
+	fmt.Fprintf(&b, "# Window over which CPU and disk I/O rates are measured, shorter than poll_interval\n")
+	fmt.Fprintf(&b, "sample_window = %q\n\n", d.SampleWindow)
+	fmt.Fprintf(&b, "# Share processes, history and actions with \"hyprtask daemon\" when it runs.\n")
+	fmt.Fprintf(&b, "# Its own poll_interval and sample_window apply then.\n")
+	fmt.Fprintf(&b, "use_daemon = %t\n\n", d.UseDaemon)
+	fmt.Fprintf(&b, "# Screen shown at startup: %q or %q\n", ScreenWorkspaces, ScreenProcesses)
+	fmt.Fprintf(&b, "default_screen = %q\n\n", d.DefaultScreen)
+	fmt.Fprintf(&b, "# Process list columns, in order. Available: %s\n", strings.Join(choices.Columns, ", "))
+	fmt.Fprintf(&b, "columns = [%s]\n\n", quoteList(defaultColumns))
+
+	fmt.Fprintf(&b, "# Key binding preset: %s. vim adds hjkl, gg/G and ctrl+u/d\n", strings.Join(choices.KeyPresets, ", "))
+	fmt.Fprintf(&b, "key_preset = %q\n\n", d.KeyPreset)
+
+	fmt.Fprintf(&b, "[sort]\n")
+	fmt.Fprintf(&b, "# One of: %s\n", strings.Join(choices.SortKeys, ", "))
+	fmt.Fprintf(&b, "key = %q\n", d.Sort.Key)
+	fmt.Fprintf(&b, "# asc, desc or none\n")
+	fmt.Fprintf(&b, "order = %q\n\n", d.Sort.Order)
+
+	fmt.Fprintf(&b, "[theme]\n")
+	fmt.Fprintf(&b, "# One of: %s\n", strings.Join(choices.Themes, ", "))
+	fmt.Fprintf(&b, "# or a theme file: a path, or a name of a .toml file in %s\n", d.ThemesDir())
+	fmt.Fprintf(&b, "# Empty picks the default theme, or monochrome when NO_COLOR is set.\n")
+	fmt.Fprintf(&b, "name = %q\n\n", d.Theme.Name)
+	fmt.Fprintf(&b, "# Color overrides on top of the theme, as #rrggbb or an ANSI color number.\n")
+	fmt.Fprintf(&b, "# Theme files use the same keys.\n")
+	fmt.Fprintf(&b, "[theme.colors]\n")
+	for _, name := range sortedKeys(d.Theme.Colors.byName()) {
+		fmt.Fprintf(&b, "# %s = \"\"\n", name)
+	}
+	fmt.Fprintf(&b, "\n")
+
+	fmt.Fprintf(&b, "# Key bindings: action = [keys]. Listed actions replace the preset's keys.\n")
+	fmt.Fprintf(&b, "# Separate keys with spaces for a sequence, e.g. \"g g\". A key may only be\n")
+	fmt.Fprintf(&b, "# bound once per screen.\n")
+	fmt.Fprintf(&b, "[keys]\n")
+	for _, action := range choices.Actions {
+		fmt.Fprintf(&b, "# %s = [%s]\n", action, quoteList(defaultKeys[action]))
+	}
+	fmt.Fprintf(&b, "\n")
+
+	fmt.Fprintf(&b, "# Desktop notifications of watchdog rules, failed actions and watched processes\n")
+	fmt.Fprintf(&b, "# that exit. Sent by \"hyprtask daemon\", or by the UI when it polls for itself.\n")
+	fmt.Fprintf(&b, "[notifications]\n")
+	fmt.Fprintf(&b, "enabled = %t\n", d.Notifications.Enabled)
+	fmt.Fprintf(&b, "# Program names or window classes whose exits are notified, e.g. [\"waybar\"]\n")
+	fmt.Fprintf(&b, "watch = []\n")
+	fmt.Fprintf(&b, "# A notification repeated within this time is dropped\n")
+	fmt.Fprintf(&b, "dedupe = %q\n\n", d.Notifications.Dedupe)
+
+	fmt.Fprintf(&b, "# Commands run on events, with sh -c. They read the event as JSON on stdin and\n")
+	fmt.Fprintf(&b, "# in HYPRTASK_* environment variables. Run by \"hyprtask daemon\", or by the UI\n")
+	fmt.Fprintf(&b, "# when it polls for itself.\n")
+	fmt.Fprintf(&b, "[hooks]\n")
+	fmt.Fprintf(&b, "# A command running longer is killed\n")
+	fmt.Fprintf(&b, "timeout = %q\n", d.Hooks.Timeout)
+	fmt.Fprintf(&b, "# Commands running at once, the others wait\n")
+	fmt.Fprintf(&b, "max_concurrent = %d\n", d.Hooks.MaxConcurrent)
+	fmt.Fprintf(&b, "# The output and exit status of every command are appended to this file\n")
+	fmt.Fprintf(&b, "log = %q\n\n", d.Hooks.Log)
+	fmt.Fprintf(&b, "# Events: %s\n", strings.Join(choices.HookEvents, ", "))
+	fmt.Fprintf(&b, "# Filters: program, class, workspace. %s needs cpu_above or mem_above,\n", HookEventWorkspaceLoad)
+	fmt.Fprintf(&b, "# the total of the workspace's windows in percent, and runs again once it dropped below.\n")
+	fmt.Fprintf(&b, "# Start long running programs through Hyprland, the command's output is closed after it.\n")
+	fmt.Fprintf(&b, "# [[hooks.on]]\n")
+	fmt.Fprintf(&b, "# event = \"process_exited\"\n")
+	fmt.Fprintf(&b, "# program = \"waybar\"\n")
+	fmt.Fprintf(&b, "# command = \"hyprctl dispatch exec waybar\"\n\n")
+
+	fmt.Fprintf(&b, "# Rules that act on busy processes and workspaces. They run in \"hyprtask daemon\",\n")
+	fmt.Fprintf(&b, "# or in the UI when it polls for itself.\n")
+	fmt.Fprintf(&b, "[watchdog]\n")
+	fmt.Fprintf(&b, "# Only write what the rules would do to the audit log\n")
+	fmt.Fprintf(&b, "dry_run = %t\n", d.Watchdog.DryRun)
+	fmt.Fprintf(&b, "# Every triggered rule is appended to this file as a JSON line\n")
+	fmt.Fprintf(&b, "audit_log = %q\n\n", d.Watchdog.AuditLog)
+	fmt.Fprintf(&b, "# A rule trips when metric (%s or %s, in percent) of a process, or its sum\n", RuleMetricCPU, RuleMetricMEM)
+	fmt.Fprintf(&b, "# over a workspace with scope = %q, stays above \"above\" for \"for\". It\n", RuleScopeWorkspace)
+	fmt.Fprintf(&b, "# resets once the metric drops below clear_below. Filters: class, program,\n")
+	fmt.Fprintf(&b, "# user, workspace. Actions: %s (with signal), %s, %s.\n", RuleActionSignal, RuleActionNotify, RuleActionCloseWindows)
+	fmt.Fprintf(&b, "# cooldown is the least time between two actions on the same subject.\n")
+	fmt.Fprintf(&b, "# [[watchdog.rules]]\n")
+	fmt.Fprintf(&b, "# name = \"firefox memory\"\n")
+	fmt.Fprintf(&b, "# class = \"firefox\"\n")
+	fmt.Fprintf(&b, "# metric = \"mem\"\n")
+	fmt.Fprintf(&b, "# above = 30\n")
+	fmt.Fprintf(&b, "# clear_below = 25\n")
+	fmt.Fprintf(&b, "# for = \"1m\"\n")
+	fmt.Fprintf(&b, "# action = \"signal\"\n")
+	fmt.Fprintf(&b, "# signal = \"STOP\"\n")
+	fmt.Fprintf(&b, "# cooldown = \"10m\"\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,180 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+const (
+	dialTimeout = time.Second
+	retryDelay  = 2 * time.Second // between attempts to reach a daemon that went away
+)
+
+// Client talks to a daemon, one connection per request
+type Client struct {
+	socket string
+}
+
+// Dial returns a client of the daemon listening on socket, or an error when
+// no daemon answers there, or one not run by this user
+func Dial(socket string) (*Client, error) {
+	if err := checkSocketDir(filepath.Dir(socket)); err != nil {
+		return nil, err
+	}
+	conn, err := dial(socket)
+	if err != nil {
+		return nil, err
+	}
+	conn.Close()
+	return &Client{socket: socket}, nil
+}
+
+// dial connects to socket, making sure the daemon runs as this user
+func dial(socket string) (net.Conn, error) {
+	conn, err := net.DialTimeout("unix", socket, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPeer(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("not connecting to the daemon on %s: %w", socket, err)
+	}
+	return conn, nil
+}
+
+// send opens a connection and writes req on it
+func (c *Client) send(req request) (net.Conn, *json.Decoder, error) {
+	conn, err := dial(c.socket)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, json.NewDecoder(conn), nil
+}
+
+// call sends req and reads its single response
+func (c *Client) call(req request) (response, error) {
+	conn, dec, err := c.send(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer conn.Close()
+	var resp response
+	if err := dec.Decode(&resp); err != nil {
+		return response{}, fmt.Errorf("read daemon response: %w", err)
+	}
+	if resp.Error != "" {
+		return response{}, remoteError(resp.Error)
+	}
+	return resp, nil
+}
+
+// Snapshot returns the daemon's latest fully sampled snapshot
+func (c *Client) Snapshot() (taskmanager.Snapshot, error) {
+	resp, err := c.call(request{Method: methodSnapshot})
+	if err != nil {
+		return taskmanager.Snapshot{}, err
+	}
+	if resp.Snapshot == nil {
+		return taskmanager.Snapshot{}, errors.New("daemon sent no snapshot")
+	}
+	return *resp.Snapshot, nil
+}
+
+// History returns a copy of the history the daemon has recorded
+func (c *Client) History() (*history.Store, error) {
+	resp, err := c.call(request{Method: methodHistory})
+	if err != nil {
+		return nil, err
+	}
+	if resp.History == nil {
+		return nil, errors.New("daemon sent no history")
+	}
+	return history.NewStoreFrom(*resp.History), nil
+}
+
+// Do runs action on the daemon
+func (c *Client) Do(action taskmanager.TaskAction) (taskmanager.ActionResult, error) {
+	wire, err := encodeAction(action)
+	if err != nil {
+		return taskmanager.ActionResult{}, err
+	}
+	resp, err := c.call(request{Method: methodAction, Action: &wire})
+	if err != nil {
+		return taskmanager.ActionResult{}, err
+	}
+	if resp.Result == nil {
+		return taskmanager.ActionResult{}, errors.New("daemon sent no result")
+	}
+	return decodeResult(action.Type, resp.Result), nil
+}
+
+// Subscribe sends the daemon's snapshots to snapshots until the connection
+// fails. Like the task manager, it skips snapshots the reader is not ready for.
+func (c *Client) Subscribe(snapshots chan<- taskmanager.Snapshot) error {
+	conn, dec, err := c.send(request{Method: methodSubscribe})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for {
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return remoteError(resp.Error)
+		}
+		if resp.Snapshot == nil {
+			continue
+		}
+		select {
+		case snapshots <- *resp.Snapshot:
+		default:
+			logger.Log.Warn("skipped daemon snapshot - viewmodel is not ready")
+		}
+	}
+}
+
+// Serve stands in for a local task manager: it forwards the daemon's
+// snapshots to snapshots, reconnecting when the daemon restarts, and runs
+// every action from actions on the daemon.
+func (c *Client) Serve(snapshots chan<- taskmanager.Snapshot, actions <-chan taskmanager.TaskAction, results chan<- taskmanager.ActionResult) {
+	go func() {
+		for action := range actions {
+			results <- c.doOrFail(action)
+		}
+	}()
+	for {
+		err := c.Subscribe(snapshots)
+		logger.Log.Warn("lost the daemon's snapshot stream, retrying", "error", err)
+		time.Sleep(retryDelay)
+	}
+}
+
+// doOrFail runs action, failing it for every process when the daemon cannot be reached
+func (c *Client) doOrFail(action taskmanager.TaskAction) taskmanager.ActionResult {
+	result, err := c.Do(action)
+	if err == nil {
+		return result
+	}
+	logger.Log.Error("daemon could not run task action", "type", action.Type, "error", err)
+	result = taskmanager.ActionResult{Type: action.Type}
+	if wire, encodeErr := encodeAction(action); encodeErr == nil {
+		for _, pid := range wire.PIDs {
+			result.Items = append(result.Items, taskmanager.ItemResult{PID: pid, Err: err})
+		}
+	}
+	return result
+}
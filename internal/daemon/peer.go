@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// checkSocketDir makes sure dir belongs to this user and nobody else can
+// enter it. Under the shared temp dir another user could create it first and
+// listen in it, or feed spoofed snapshots to clients.
+func checkSocketDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !info.IsDir() || !ok {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	if int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%s belongs to uid %d, not to this user", dir, stat.Uid)
+	}
+	if perm := info.Mode().Perm(); perm != 0700 {
+		return fmt.Errorf("%s has mode %#o, it has to be 0700", dir, perm)
+	}
+	return nil
+}
+
+// checkPeer makes sure the other end of conn runs as this user, read from the
+// kernel with SO_PEERCRED
+func checkPeer(conn net.Conn) error {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return fmt.Errorf("not a unix socket connection")
+	}
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var cred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err == nil {
+		err = credErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not read peer credentials: %w", err)
+	}
+	if int(cred.Uid) != os.Getuid() {
+		return fmt.Errorf("peer pid %d runs as uid %d, not as this user", cred.Pid, cred.Uid)
+	}
+	return nil
+}
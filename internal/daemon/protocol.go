@@ -0,0 +1,209 @@
+// Package daemon shares one task manager and its history between every view:
+// "hyprtask daemon" serves them on a Unix socket and the UI, the commands and
+// the bar connect to it when it runs.
+//
+// Every connection carries one request, a JSON object on one line, answered
+// by JSON lines:
+//
+//	{"method": "snapshot"}   one {"snapshot": …}, the latest fully sampled one
+//	{"method": "subscribe"}  {"snapshot": …} with the latest, then one per update
+//	{"method": "history"}    one {"history": …}, every recorded series
+//	{"method": "action", "action": {"type": "signal", "pids": [42], "signal": 15}}
+//	                         one {"result": {"type": "signal", "items": [{"pid": 42}]}}
+//
+// Failed requests are answered with {"error": "…"}. Snapshots use the JSON of
+// the schema package.
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+const (
+	methodSnapshot  = "snapshot"
+	methodSubscribe = "subscribe"
+	methodHistory   = "history"
+	methodAction    = "action"
+)
+
+type request struct {
+	Method string      `json:"method"`
+	Action *wireAction `json:"action,omitempty"`
+}
+
+type response struct {
+	Snapshot *taskmanager.Snapshot `json:"snapshot,omitempty"`
+	History  *history.Export       `json:"history,omitempty"`
+	Result   *wireResult           `json:"result,omitempty"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// wireAction is a task action with its payload flattened. Fields that do not
+// apply to the type are left out.
+type wireAction struct {
+	Type      string `json:"type"` // see actionNames
+	PIDs      []int  `json:"pids"`
+	Tree      bool   `json:"tree,omitempty"`    // include descendants: renice, io_priority and affinity
+	Threads   bool   `json:"threads,omitempty"` // the pids are thread IDs, pinned one by one: affinity
+	Signal    int    `json:"signal,omitempty"`  // signal number
+	Nice      int    `json:"nice,omitempty"`
+	IOClass   string `json:"io_class,omitempty"` // realtime, best-effort or idle
+	IOLevel   int    `json:"io_level,omitempty"`
+	CPUs      []int  `json:"cpus,omitempty"`
+	Workspace string `json:"workspace,omitempty"` // move_window target, as in hyprctl dispatch
+}
+
+type wireResult struct {
+	Type  string     `json:"type"`
+	Items []wireItem `json:"items"`
+}
+
+type wireItem struct {
+	PID   int    `json:"pid"`
+	Error string `json:"error,omitempty"`
+}
+
+var actionNames = map[taskmanager.TaskActionType]string{
+	taskmanager.TaskActionSignal:      "signal",
+	taskmanager.TaskActionRenice:      "renice",
+	taskmanager.TaskActionIOPriority:  "io_priority",
+	taskmanager.TaskActionAffinity:    "affinity",
+	taskmanager.TaskActionCloseWindow: "close_window",
+	taskmanager.TaskActionFocusWindow: "focus_window",
+	taskmanager.TaskActionMoveWindow:  "move_window",
+}
+
+var ioClasses = []taskmanager.IOClass{taskmanager.IOClassRealtime, taskmanager.IOClassBestEffort, taskmanager.IOClassIdle}
+
+// SocketPath is where the daemon listens: $XDG_RUNTIME_DIR/hyprtask/daemon.sock,
+// or a per-user directory under the temp dir when XDG_RUNTIME_DIR is not set
+func SocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		return filepath.Join(os.TempDir(), fmt.Sprintf("hyprtask-%d", os.Getuid()), "daemon.sock")
+	}
+	return filepath.Join(dir, "hyprtask", "daemon.sock")
+}
+
+func encodeAction(action taskmanager.TaskAction) (wireAction, error) {
+	wire := wireAction{Type: actionNames[action.Type]}
+	switch p := action.Payload.(type) {
+	case taskmanager.SignalPayload:
+		wire.PIDs, wire.Signal = p.PIDs, int(p.Signal)
+	case taskmanager.RenicePayload:
+		wire.PIDs, wire.Tree, wire.Nice = p.PIDs, p.Scope == taskmanager.ScopeTree, p.Nice
+	case taskmanager.IOPriorityPayload:
+		wire.PIDs, wire.Tree, wire.IOClass, wire.IOLevel = p.PIDs, p.Scope == taskmanager.ScopeTree, p.Class.String(), p.Level
+	case taskmanager.AffinityPayload:
+		wire.PIDs, wire.Tree, wire.Threads, wire.CPUs = p.PIDs, p.Scope == taskmanager.ScopeTree, p.Scope == taskmanager.ScopeThread, p.CPUs
+	case taskmanager.CloseWindowPayload:
+		wire.PIDs = p.PIDs
+	case taskmanager.FocusWindowPayload:
+		wire.PIDs = []int{p.PID}
+	case taskmanager.MoveWindowPayload:
+		wire.PIDs, wire.Workspace = p.PIDs, p.Workspace
+	default:
+		return wireAction{}, fmt.Errorf("task action %s has an unexpected payload %T", action.Type, action.Payload)
+	}
+	return wire, nil
+}
+
+func decodeAction(wire wireAction) (taskmanager.TaskAction, error) {
+	actionType, ok := actionType(wire.Type)
+	if !ok {
+		return taskmanager.TaskAction{}, fmt.Errorf("unknown action type %q", wire.Type)
+	}
+	if len(wire.PIDs) == 0 {
+		return taskmanager.TaskAction{}, fmt.Errorf("action %s names no pids", wire.Type)
+	}
+	scope := taskmanager.ScopeProcess
+	if wire.Tree {
+		scope = taskmanager.ScopeTree
+	}
+
+	action := taskmanager.TaskAction{Type: actionType}
+	switch actionType {
+	case taskmanager.TaskActionSignal:
+		if wire.Signal <= 0 {
+			return taskmanager.TaskAction{}, fmt.Errorf("signal %d is not a signal number", wire.Signal)
+		}
+		action.Payload = taskmanager.SignalPayload{PIDs: wire.PIDs, Signal: syscall.Signal(wire.Signal)}
+	case taskmanager.TaskActionRenice:
+		action.Payload = taskmanager.RenicePayload{PIDs: wire.PIDs, Scope: scope, Nice: wire.Nice}
+	case taskmanager.TaskActionIOPriority:
+		class, ok := ioClass(wire.IOClass)
+		if !ok {
+			return taskmanager.TaskAction{}, fmt.Errorf("unknown io class %q (valid: realtime, best-effort, idle)", wire.IOClass)
+		}
+		action.Payload = taskmanager.IOPriorityPayload{PIDs: wire.PIDs, Scope: scope, Class: class, Level: wire.IOLevel}
+	case taskmanager.TaskActionAffinity:
+		if wire.Threads {
+			scope = taskmanager.ScopeThread
+		}
+		action.Payload = taskmanager.AffinityPayload{PIDs: wire.PIDs, Scope: scope, CPUs: wire.CPUs}
+	case taskmanager.TaskActionCloseWindow:
+		action.Payload = taskmanager.CloseWindowPayload{PIDs: wire.PIDs}
+	case taskmanager.TaskActionFocusWindow:
+		action.Payload = taskmanager.FocusWindowPayload{PID: wire.PIDs[0]}
+	case taskmanager.TaskActionMoveWindow:
+		if wire.Workspace == "" {
+			return taskmanager.TaskAction{}, fmt.Errorf("move_window needs a workspace")
+		}
+		action.Payload = taskmanager.MoveWindowPayload{PIDs: wire.PIDs, Workspace: wire.Workspace}
+	}
+	return action, nil
+}
+
+func actionType(name string) (taskmanager.TaskActionType, bool) {
+	for t, n := range actionNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+func ioClass(name string) (taskmanager.IOClass, bool) {
+	for _, class := range ioClasses {
+		if class.String() == name {
+			return class, true
+		}
+	}
+	return taskmanager.IOClassNone, false
+}
+
+func encodeResult(result taskmanager.ActionResult) *wireResult {
+	wire := &wireResult{Type: actionNames[result.Type], Items: make([]wireItem, len(result.Items))}
+	for i, item := range result.Items {
+		wire.Items[i] = wireItem{PID: item.PID}
+		if item.Err != nil {
+			wire.Items[i].Error = item.Err.Error()
+		}
+	}
+	return wire
+}
+
+// decodeResult rebuilds a result. Errors keep their message, not their type.
+func decodeResult(actionType taskmanager.TaskActionType, wire *wireResult) taskmanager.ActionResult {
+	result := taskmanager.ActionResult{Type: actionType, Items: make([]taskmanager.ItemResult, len(wire.Items))}
+	for i, item := range wire.Items {
+		result.Items[i] = taskmanager.ItemResult{PID: item.PID}
+		if item.Error != "" {
+			result.Items[i].Err = remoteError(item.Error)
+		}
+	}
+	return result
+}
+
+// remoteError is an error reported by the daemon
+type remoteError string
+
+func (e remoteError) Error() string {
+	return string(e)
+}
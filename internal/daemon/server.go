@@ -0,0 +1,179 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+const (
+	requestTimeout  = 5 * time.Second // for the request line
+	snapshotTimeout = time.Minute     // for the first fully sampled snapshot
+)
+
+// Server answers clients from one task manager, recording its history
+type Server struct {
+	tm      *taskmanager.TaskManager
+	history *history.Store
+
+	mu     sync.RWMutex
+	latest *taskmanager.Snapshot // latest fully sampled snapshot
+	ready  chan struct{}         // closed once latest is set
+}
+
+func NewServer(tm *taskmanager.TaskManager, store *history.Store) *Server {
+	return &Server{tm: tm, history: store, ready: make(chan struct{})}
+}
+
+// Listen creates the socket at path. A socket left behind by a daemon that
+// died is replaced, one in use is an error. The directory of path has to
+// belong to this user with mode 0700.
+func Listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err := checkSocketDir(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("refusing to listen: %w", err)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a daemon is already listening on %s", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return net.Listen("unix", path)
+}
+
+// Serve records snapshots and answers clients on l until l is closed. Only
+// clients running as this user are answered.
+func (s *Server) Serve(l net.Listener) error {
+	snapshots, unsubscribe := s.tm.Subscribe()
+	defer unsubscribe()
+	go s.record(snapshots)
+
+	for {
+		conn, err := l.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkPeer(conn); err != nil {
+			logger.Log.Warn("rejected daemon client", "error", err)
+			conn.Close()
+			continue
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) record(snapshots <-chan taskmanager.Snapshot) {
+	for snapshot := range snapshots {
+		if !snapshot.Accurate {
+			continue
+		}
+		s.history.Record(snapshot)
+		s.mu.Lock()
+		first := s.latest == nil
+		s.latest = &snapshot
+		s.mu.Unlock()
+		if first {
+			close(s.ready)
+		}
+	}
+}
+
+func (s *Server) latestSnapshot() (taskmanager.Snapshot, error) {
+	select {
+	case <-s.ready:
+	case <-time.After(snapshotTimeout):
+		return taskmanager.Snapshot{}, errors.New("no snapshot yet")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return *s.latest, nil
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	enc := json.NewEncoder(conn)
+
+	var req request
+	conn.SetReadDeadline(time.Now().Add(requestTimeout))
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err == nil {
+		err = json.Unmarshal(line, &req)
+	}
+	if err != nil {
+		enc.Encode(response{Error: "invalid request: " + err.Error()})
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	var resp response
+	switch req.Method {
+	case methodSnapshot:
+		snapshot, err := s.latestSnapshot()
+		resp = response{Snapshot: &snapshot}
+		if err != nil {
+			resp = response{Error: err.Error()}
+		}
+	case methodSubscribe:
+		s.stream(conn, enc)
+		return
+	case methodHistory:
+		export := s.history.Export()
+		resp = response{History: &export}
+	case methodAction:
+		resp = s.do(req.Action)
+	default:
+		resp = response{Error: fmt.Sprintf("unknown method %q", req.Method)}
+	}
+	if err := enc.Encode(resp); err != nil {
+		logger.Log.Warn("could not answer daemon client", "method", req.Method, "error", err)
+	}
+}
+
+// stream sends the latest snapshot, then every new one until the client goes away
+func (s *Server) stream(conn net.Conn, enc *json.Encoder) {
+	snapshots, unsubscribe := s.tm.Subscribe()
+	defer unsubscribe()
+
+	s.mu.RLock()
+	latest := s.latest
+	s.mu.RUnlock()
+	if latest != nil {
+		if err := enc.Encode(response{Snapshot: latest}); err != nil {
+			return
+		}
+	}
+	for snapshot := range snapshots {
+		if err := enc.Encode(response{Snapshot: &snapshot}); err != nil {
+			logger.Log.Info("daemon client went away", "error", err)
+			return
+		}
+	}
+}
+
+func (s *Server) do(wire *wireAction) response {
+	if wire == nil {
+		return response{Error: "action request without an action"}
+	}
+	action, err := decodeAction(*wire)
+	if err != nil {
+		return response{Error: err.Error()}
+	}
+	return response{Result: encodeResult(s.tm.Do(action))}
+}
@@ -0,0 +1,249 @@
+// Package exporter exposes workspace and process group usage in the
+// OpenMetrics text format, for Prometheus to scrape:
+//
+//	hyprtask_workspace_cpu_percent{workspace_id="3",workspace="3",monitor="0"} 12.5
+//	hyprtask_group_cpu_percent{group="firefox",workspace_id="3",workspace="3",monitor="0",class="firefox"} 11.2
+//
+// Groups are formed per workspace, so a class open on two workspaces is two
+// series. Processes without a window belong to workspace "". Only the
+// busiest groups, by CPU and then memory, get their own series; the rest are
+// summed into the group "other" to keep the number of series bounded.
+package exporter
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+const (
+	openMetricsType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	textType        = "text/plain; version=0.0.4; charset=utf-8" // Prometheus' older text format
+)
+
+// otherGroup sums the groups past the top N
+const otherGroup = "other"
+
+// Options control the cardinality of the group series
+type Options struct {
+	GroupBy viewmodel.GroupBy // how processes are grouped within a workspace, not GroupByNone
+	Top     int               // groups with their own series; 0 for all
+}
+
+// Exporter serves the latest display data it was given on /metrics
+type Exporter struct {
+	opts Options
+
+	mu     sync.RWMutex
+	latest *viewmodel.DisplayData
+}
+
+func NewExporter(opts Options) *Exporter {
+	return &Exporter{opts: opts}
+}
+
+// Update replaces the data served to the next scrape
+func (e *Exporter) Update(data viewmodel.DisplayData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.latest = &data
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	latest := e.latest
+	e.mu.RUnlock()
+	if latest == nil {
+		http.Error(w, "no data yet, the first sample is still being taken", http.StatusServiceUnavailable)
+		return
+	}
+
+	openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		w.Header().Set("Content-Type", openMetricsType)
+	} else {
+		w.Header().Set("Content-Type", textType)
+	}
+	Write(w, *latest, e.opts, openMetrics)
+}
+
+// family is one metric with its series
+type family struct {
+	name   string
+	help   string
+	series []series
+}
+
+type series struct {
+	labels []label
+	value  float64
+}
+
+type label struct {
+	name, value string
+}
+
+// Write writes data as metrics. Only the OpenMetrics format ends with "# EOF".
+func Write(w io.Writer, data viewmodel.DisplayData, opts Options, openMetrics bool) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range families(data, opts) {
+		writeFamily(bw, f)
+	}
+	if openMetrics {
+		bw.WriteString("# EOF\n")
+	}
+	return bw.Flush()
+}
+
+func writeFamily(w *bufio.Writer, f family) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", f.name)
+	for _, s := range f.series {
+		w.WriteString(f.name)
+		if len(s.labels) > 0 {
+			w.WriteByte('{')
+			for i, l := range s.labels {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				fmt.Fprintf(w, "%s=\"%s\"", l.name, escape(l.value))
+			}
+			w.WriteByte('}')
+		}
+		w.WriteByte(' ')
+		w.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+		w.WriteByte('\n')
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+// usage is what every workspace and group series reports
+type usage struct {
+	labels    []label
+	cpu       float64
+	memBytes  float64
+	ioRead    float64
+	ioWrite   float64
+	processes int
+}
+
+func families(data viewmodel.DisplayData, opts Options) []family {
+	memTotal := float64(data.System.MemTotal)
+	system := []family{
+		{name: "hyprtask_system_cpu_percent", help: "Busy percentage across all cores.",
+			series: []series{{value: data.System.CPU}}},
+		{name: "hyprtask_system_memory_bytes", help: "Memory in use.",
+			series: []series{{value: float64(data.System.MemUsed)}}},
+	}
+
+	var workspaces, groups []usage
+	for _, ws := range data.Hypr.Workspaces {
+		labels := workspaceLabels(ws)
+		workspaces = append(workspaces, sum(labels, ws.ActiveProcs, memTotal))
+		groups = append(groups, groupUsage(labels, ws.ActiveProcs, opts.GroupBy, memTotal)...)
+	}
+	var windowless []taskmanager.TaskProcess
+	for _, proc := range data.All {
+		if proc.Meta == nil || proc.Meta.Hyprland == nil {
+			windowless = append(windowless, proc)
+		}
+	}
+	groups = append(groups, groupUsage(workspaceLabels(nil), windowless, opts.GroupBy, memTotal)...)
+	groups = top(groups, opts.Top)
+
+	return append(system, append(
+		usageFamilies("hyprtask_workspace", "Hyprland workspace", workspaces),
+		usageFamilies("hyprtask_group", "process group", groups)...)...)
+}
+
+// workspaceLabels identify ws, or no workspace when ws is nil
+func workspaceLabels(ws *viewmodel.WorkspaceData) []label {
+	if ws == nil {
+		return []label{{"workspace_id", ""}, {"workspace", ""}, {"monitor", ""}}
+	}
+	monitor := ""
+	if len(ws.ActiveProcs) > 0 {
+		monitor = strconv.Itoa(ws.ActiveProcs[0].Meta.Hyprland.Monitor)
+	}
+	return []label{{"workspace_id", strconv.Itoa(ws.WorkspaceID)}, {"workspace", ws.WorkspaceName}, {"monitor", monitor}}
+}
+
+func sum(labels []label, procs []taskmanager.TaskProcess, memTotal float64) usage {
+	u := usage{labels: labels, processes: len(procs)}
+	for _, proc := range procs {
+		u.cpu += proc.Metrics.CPU
+		u.memBytes += proc.Metrics.MEM / 100 * memTotal
+		u.ioRead += proc.Metrics.IORead
+		u.ioWrite += proc.Metrics.IOWrite
+	}
+	return u
+}
+
+// groupUsage groups procs, which share the workspace labels, by. The class
+// label is the group's when grouping by class, else that of its oldest window.
+func groupUsage(labels []label, procs []taskmanager.TaskProcess, by viewmodel.GroupBy, memTotal float64) []usage {
+	var groups []usage
+	for _, g := range viewmodel.Group(procs, by) {
+		class := ""
+		if w := g.Aggregate.Meta; w != nil && w.Hyprland != nil {
+			class = w.Hyprland.Class
+		}
+		groupLabels := append([]label{{"group", g.Key}}, labels...)
+		groups = append(groups, sum(append(groupLabels, label{"class", class}), g.Members, memTotal))
+	}
+	return groups
+}
+
+// top keeps the n busiest groups by CPU, then memory, and sums the rest into "other"
+func top(groups []usage, n int) []usage {
+	slices.SortStableFunc(groups, func(a, b usage) int {
+		return cmp.Or(cmp.Compare(b.cpu, a.cpu), cmp.Compare(b.memBytes, a.memBytes))
+	})
+	if n <= 0 || len(groups) <= n {
+		return groups
+	}
+	other := usage{labels: []label{{"group", otherGroup}, {"workspace_id", ""}, {"workspace", ""}, {"monitor", ""}, {"class", ""}}}
+	for _, g := range groups[n:] {
+		other.cpu += g.cpu
+		other.memBytes += g.memBytes
+		other.ioRead += g.ioRead
+		other.ioWrite += g.ioWrite
+		other.processes += g.processes
+	}
+	return append(groups[:n:n], other)
+}
+
+func usageFamilies(prefix, what string, usages []usage) []family {
+	metrics := []struct {
+		suffix, help string
+		value        func(usage) float64
+	}{
+		{"_cpu_percent", "CPU percentage of the %s's processes.", func(u usage) float64 { return u.cpu }},
+		{"_memory_bytes", "Resident memory of the %s's processes.", func(u usage) float64 { return u.memBytes }},
+		{"_io_read_bytes_per_second", "Disk reads of the %s's processes.", func(u usage) float64 { return u.ioRead }},
+		{"_io_write_bytes_per_second", "Disk writes of the %s's processes.", func(u usage) float64 { return u.ioWrite }},
+		{"_processes", "Processes in the %s.", func(u usage) float64 { return float64(u.processes) }},
+	}
+	families := make([]family, len(metrics))
+	for i, m := range metrics {
+		families[i] = family{name: prefix + m.suffix, help: fmt.Sprintf(m.help, what)}
+		for _, u := range usages {
+			families[i].series = append(families[i].series, series{labels: u.labels, value: m.value(u)})
+		}
+	}
+	return families
+}
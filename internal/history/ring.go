@@ -0,0 +1,65 @@
+package history
+
+import "time"
+
+type Sample struct {
+	Time    time.Time `json:"time"`
+	CPU     float64   `json:"cpu"`
+	MEM     float64   `json:"mem"`
+	IORead  float64   `json:"io_read"`  // bytes/s
+	IOWrite float64   `json:"io_write"` // bytes/s
+}
+
+// Ring is a fixed-capacity buffer that keeps the most recent samples
+type Ring struct {
+	samples []Sample
+	start   int // index of the oldest sample
+	size    int
+}
+
+func NewRing(capacity int) *Ring {
+	return &Ring{samples: make([]Sample, capacity)}
+}
+
+// Push appends s. A sample older than the latest one rewinds the ring first,
+// dropping the samples taken at or after it, as when a replay seeks back.
+func (r *Ring) Push(s Sample) {
+	if len(r.samples) == 0 {
+		return
+	}
+	for r.size > 0 && !r.samples[(r.start+r.size-1)%len(r.samples)].Time.Before(s.Time) {
+		r.size--
+	}
+	end := (r.start + r.size) % len(r.samples)
+	r.samples[end] = s
+	if r.size < len(r.samples) {
+		r.size++
+	} else {
+		// Full: overwrite the oldest sample
+		r.start = (r.start + 1) % len(r.samples)
+	}
+}
+
+func (r *Ring) Len() int {
+	return r.size
+}
+
+// Samples returns a copy of the buffered samples, oldest first
+func (r *Ring) Samples() []Sample {
+	out := make([]Sample, r.size)
+	for i := range r.size {
+		out[i] = r.samples[(r.start+i)%len(r.samples)]
+	}
+	return out
+}
+
+// Since returns a copy of the samples taken at or after t, oldest first
+func (r *Ring) Since(t time.Time) []Sample {
+	samples := r.Samples()
+	for i, s := range samples {
+		if !s.Time.Before(t) {
+			return samples[i:]
+		}
+	}
+	return nil
+}
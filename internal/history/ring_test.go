@@ -0,0 +1,68 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+var base = time.Date(2026, 10, 19, 14, 3, 0, 0, time.UTC)
+
+func at(seconds int) Sample {
+	return Sample{Time: base.Add(time.Duration(seconds) * time.Second), CPU: float64(seconds)}
+}
+
+// seconds returns the times of the samples in r, in seconds after base
+func seconds(r *Ring) []int {
+	var out []int
+	for _, s := range r.Samples() {
+		out = append(out, int(s.Time.Sub(base)/time.Second))
+	}
+	return out
+}
+
+func TestRingPush(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		pushes   []int
+		want     []int
+	}{
+		{name: "in order", capacity: 4, pushes: []int{0, 5, 10}, want: []int{0, 5, 10}},
+		{name: "full drops the oldest", capacity: 3, pushes: []int{0, 5, 10, 15, 20}, want: []int{10, 15, 20}},
+		{name: "older rewinds", capacity: 4, pushes: []int{0, 5, 10, 15, 7}, want: []int{0, 5, 7}},
+		{name: "same time replaces", capacity: 4, pushes: []int{0, 5, 5}, want: []int{0, 5}},
+		{name: "older than all empties first", capacity: 4, pushes: []int{10, 15, 3}, want: []int{3}},
+		{name: "rewind after wrapping", capacity: 3, pushes: []int{0, 5, 10, 15, 20, 12, 13}, want: []int{10, 12, 13}},
+		{name: "zero capacity", capacity: 0, pushes: []int{0, 5}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRing(tt.capacity)
+			for _, s := range tt.pushes {
+				r.Push(at(s))
+			}
+			got := seconds(r)
+			if len(got) != len(tt.want) || r.Len() != len(tt.want) {
+				t.Fatalf("got %v (len %d), want %v", got, r.Len(), tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRingSince(t *testing.T) {
+	r := NewRing(4)
+	for _, s := range []int{0, 5, 10, 15} {
+		r.Push(at(s))
+	}
+	if got := r.Since(base.Add(5 * time.Second)); len(got) != 3 || got[0].CPU != 5 {
+		t.Fatalf("Since(5s) = %v, want the samples from 5s", got)
+	}
+	if got := r.Since(base.Add(time.Minute)); got != nil {
+		t.Fatalf("Since(1m) = %v, want nil", got)
+	}
+}
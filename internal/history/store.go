@@ -0,0 +1,194 @@
+package history
+
+import (
+	"sync"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+const (
+	// DefaultCapacity keeps 30 minutes of samples at the default 5s poll interval
+	DefaultCapacity = 360
+)
+
+// Store keeps bounded per-process, per-workspace and system-wide histories.
+// It is safe for concurrent use: the viewmodel records while the UI reads.
+type Store struct {
+	capacity   int
+	procs      map[int]*Ring // PID -> samples
+	workspaces map[int]*Ring // workspace ID -> summed samples of its processes
+	system     *Ring
+
+	mu sync.RWMutex
+}
+
+func NewStore(capacity int) *Store {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Store{
+		capacity:   capacity,
+		procs:      make(map[int]*Ring),
+		workspaces: make(map[int]*Ring),
+		system:     NewRing(capacity),
+	}
+}
+
+// Record appends one sample per process, workspace and the system from the snapshot.
+// Histories of processes and workspaces missing from the snapshot are dropped.
+func (s *Store) Record(snapshot taskmanager.Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts := snapshot.Timestamp
+	seenProcs := make(map[int]bool, len(snapshot.Processes))
+	workspaceTotals := make(map[int]Sample)
+	systemSample := Sample{Time: ts, CPU: snapshot.System.CPU, MEM: snapshot.System.MEM}
+
+	for _, proc := range snapshot.Processes {
+		sample := Sample{
+			Time:    ts,
+			CPU:     proc.Metrics.CPU,
+			MEM:     proc.Metrics.MEM,
+			IORead:  proc.Metrics.IORead,
+			IOWrite: proc.Metrics.IOWrite,
+		}
+		s.ring(s.procs, proc.PID).Push(sample)
+		seenProcs[proc.PID] = true
+		systemSample.IORead += sample.IORead
+		systemSample.IOWrite += sample.IOWrite
+
+		if proc.Meta == nil || proc.Meta.Hyprland == nil {
+			continue
+		}
+		wID := proc.Meta.Hyprland.Workspace.ID
+		total := workspaceTotals[wID]
+		total.Time = ts
+		total.CPU += sample.CPU
+		total.MEM += sample.MEM
+		total.IORead += sample.IORead
+		total.IOWrite += sample.IOWrite
+		workspaceTotals[wID] = total
+	}
+
+	for wID, total := range workspaceTotals {
+		s.ring(s.workspaces, wID).Push(total)
+	}
+
+	s.system.Push(systemSample)
+
+	for pid := range s.procs {
+		if !seenProcs[pid] {
+			delete(s.procs, pid)
+		}
+	}
+	for wID := range s.workspaces {
+		if _, ok := workspaceTotals[wID]; !ok {
+			delete(s.workspaces, wID)
+		}
+	}
+}
+
+// Process returns the samples recorded for a PID, oldest first
+func (s *Store) Process(pid int) []Sample {
+	return s.samples(s.procs, pid)
+}
+
+// Workspace returns the samples recorded for a workspace, oldest first
+func (s *Store) Workspace(id int) []Sample {
+	return s.samples(s.workspaces, id)
+}
+
+// System returns the system-wide samples, oldest first
+func (s *Store) System() []Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.system.Samples()
+}
+
+// Capacity returns the number of samples kept per series
+func (s *Store) Capacity() int {
+	return s.capacity
+}
+
+func (s *Store) samples(series map[int]*Ring, id int) []Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if r, ok := series[id]; ok {
+		return r.Samples()
+	}
+	return nil
+}
+
+// ring returns the ring for id, creating it if needed. Callers must hold the write lock.
+func (s *Store) ring(series map[int]*Ring, id int) *Ring {
+	r, ok := series[id]
+	if !ok {
+		r = NewRing(s.capacity)
+		series[id] = r
+	}
+	return r
+}
+
+// Export is a copy of every series of a store, oldest samples first. It is how
+// the daemon hands its history to the views that connect to it.
+type Export struct {
+	Capacity   int              `json:"capacity"`
+	Processes  map[int][]Sample `json:"processes"`  // by PID
+	Workspaces map[int][]Sample `json:"workspaces"` // by workspace ID
+	System     []Sample         `json:"system"`
+}
+
+// Export copies every series
+func (s *Store) Export() Export {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Export{
+		Capacity:   s.capacity,
+		Processes:  exportSeries(s.procs),
+		Workspaces: exportSeries(s.workspaces),
+		System:     s.system.Samples(),
+	}
+}
+
+// NewStoreFrom builds a store holding the series of e. Series longer than the
+// capacity keep their most recent samples.
+func NewStoreFrom(e Export) *Store {
+	s := NewStore(e.Capacity)
+	for pid, samples := range e.Processes {
+		pushAll(s.ring(s.procs, pid), samples)
+	}
+	for id, samples := range e.Workspaces {
+		pushAll(s.ring(s.workspaces, id), samples)
+	}
+	pushAll(s.system, e.System)
+	return s
+}
+
+func exportSeries(series map[int]*Ring) map[int][]Sample {
+	out := make(map[int][]Sample, len(series))
+	for id, r := range series {
+		out[id] = r.Samples()
+	}
+	return out
+}
+
+func pushAll(r *Ring, samples []Sample) {
+	for _, sample := range samples {
+		r.Push(sample)
+	}
+}
+
+// Values extracts one metric from a series of samples
+func Values(samples []Sample, metric func(Sample) float64) []float64 {
+	out := make([]float64, len(samples))
+	for i, sample := range samples {
+		out[i] = metric(sample)
+	}
+	return out
+}
+
+// Metric accessors for use with Values
+func CPU(s Sample) float64 { return s.CPU }
+func MEM(s Sample) float64 { return s.MEM }
+func IO(s Sample) float64  { return s.IORead + s.IOWrite }
@@ -0,0 +1,120 @@
+package hooks
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"golang.org/x/sys/unix"
+)
+
+// payload is the event as a command reads it on stdin
+type payload struct {
+	Event     string                   `json:"event"`
+	Time      time.Time                `json:"time"`
+	Process   *taskmanager.TaskProcess `json:"process,omitempty"`   // process and window events
+	Workspace *workspaceLoad           `json:"workspace,omitempty"` // workspace_load
+	Action    *action                  `json:"action,omitempty"`    // action
+}
+
+type workspaceLoad struct {
+	ID   int     `json:"id"`
+	Name string  `json:"name"`
+	CPU  float64 `json:"cpu"`
+	MEM  float64 `json:"mem"`
+}
+
+type action struct {
+	Type   string    `json:"type"` // as in the UI: "signal", "close window", ...
+	Signal string    `json:"signal,omitempty"`
+	PIDs   []int     `json:"pids"`
+	Failed []failure `json:"failed,omitempty"`
+}
+
+type failure struct {
+	PID   int    `json:"pid"`
+	Error string `json:"error"`
+}
+
+// EventNames lists the events hooks can run on
+func EventNames() []string {
+	return []string{
+		string(taskmanager.EventProcessStarted),
+		string(taskmanager.EventProcessExited),
+		string(taskmanager.EventWindowOpened),
+		string(taskmanager.EventWindowClosed),
+		config.HookEventWorkspaceLoad,
+		string(taskmanager.EventAction),
+	}
+}
+
+func newPayload(event taskmanager.Event) payload {
+	p := payload{Event: string(event.Type), Time: event.Time}
+	if event.Type != taskmanager.EventAction {
+		proc := event.Process
+		p.Process = &proc
+		return p
+	}
+
+	a := &action{Type: event.Action.Type.String(), PIDs: []int{}}
+	if signal, ok := event.Action.Payload.(taskmanager.SignalPayload); ok {
+		a.Signal = unix.SignalName(signal.Signal)
+	}
+	for _, item := range event.Result.Items {
+		a.PIDs = append(a.PIDs, item.PID)
+		if item.Err != nil {
+			a.Failed = append(a.Failed, failure{PID: item.PID, Error: item.Err.Error()})
+		}
+	}
+	p.Action = a
+	return p
+}
+
+// env returns the event as HYPRTASK_* environment variables
+func (p payload) env() []string {
+	env := []string{
+		"HYPRTASK_EVENT=" + p.Event,
+		"HYPRTASK_TIME=" + p.Time.Format(time.RFC3339),
+	}
+	if proc := p.Process; proc != nil {
+		env = append(env,
+			"HYPRTASK_PID="+strconv.Itoa(proc.PID),
+			"HYPRTASK_PPID="+strconv.Itoa(proc.PPID),
+			"HYPRTASK_PROGRAM="+proc.ProgramName,
+			"HYPRTASK_USER="+proc.User,
+			"HYPRTASK_COMMAND_LINE="+proc.CommandLine,
+		)
+		if proc.Meta != nil && proc.Meta.Hyprland != nil {
+			w := proc.Meta.Hyprland
+			env = append(env,
+				"HYPRTASK_CLASS="+w.Class,
+				"HYPRTASK_TITLE="+w.Title,
+				"HYPRTASK_WORKSPACE="+w.Workspace.Name,
+				"HYPRTASK_WORKSPACE_ID="+strconv.Itoa(w.Workspace.ID),
+			)
+		}
+	}
+	if ws := p.Workspace; ws != nil {
+		env = append(env,
+			"HYPRTASK_WORKSPACE="+ws.Name,
+			"HYPRTASK_WORKSPACE_ID="+strconv.Itoa(ws.ID),
+			"HYPRTASK_CPU="+strconv.FormatFloat(ws.CPU, 'f', 1, 64),
+			"HYPRTASK_MEM="+strconv.FormatFloat(ws.MEM, 'f', 1, 64),
+		)
+	}
+	if a := p.Action; a != nil {
+		pids := make([]string, len(a.PIDs))
+		for i, pid := range a.PIDs {
+			pids[i] = strconv.Itoa(pid)
+		}
+		env = append(env,
+			"HYPRTASK_ACTION="+a.Type,
+			"HYPRTASK_SIGNAL="+a.Signal,
+			"HYPRTASK_PIDS="+strings.Join(pids, " "),
+			"HYPRTASK_FAILED="+strconv.Itoa(len(a.Failed)),
+		)
+	}
+	return env
+}
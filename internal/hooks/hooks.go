@@ -0,0 +1,364 @@
+// Package hooks runs the commands of the [hooks] config table when processes
+// start and exit, windows open and close, a workspace gets busy or an action
+// is taken.
+//
+// A command runs with sh -c and reads the event as JSON on stdin:
+//
+//	{"event": "process_exited", "time": "2026-10-19T14:03:05Z", "process": {"pid": 4242, "program": "waybar", …}}
+//
+// The same fields are in HYPRTASK_* environment variables, e.g.
+// HYPRTASK_EVENT, HYPRTASK_PID, HYPRTASK_PROGRAM and HYPRTASK_CLASS. At most
+// max_concurrent commands run at once; events beyond a full queue are dropped.
+// Processes the commands start are left out, so a hook cannot trigger itself.
+// Commands are killed, with their children, after the timeout. What they
+// print is appended to the hooks log with their exit status.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	queueSize = 64
+	maxOutput = 64 << 10 // bytes of a command's output that are logged
+
+	// how long the process group of a finished command is remembered, for the
+	// exits of what it left behind
+	groupMemory = time.Minute
+)
+
+// Runner runs hooks on events
+type Runner struct {
+	hooks   []config.HookConfig
+	timeout time.Duration
+	jobs    chan job
+	log     *slog.Logger
+
+	byPID map[int]taskmanager.TaskProcess // of the latest accurate snapshot, to filter actions
+	busy  map[busyKey]bool                // workspaces above a workspace_load hook's threshold
+
+	mu       sync.Mutex
+	groups   map[int]time.Time // process groups of commands, by when they finished, zero while running
+	hookPIDs map[int]bool      // processes seen in those groups, until they exit
+}
+
+type job struct {
+	hook    config.HookConfig
+	payload payload
+}
+
+type busyKey struct {
+	hook      int
+	workspace int
+}
+
+// NewRunner opens the hooks log and starts the workers
+func NewRunner(cfg config.HooksConfig) (*Runner, error) {
+	if err := os.MkdirAll(filepath.Dir(cfg.Log), 0755); err != nil {
+		return nil, fmt.Errorf("could not open the hooks log: %w", err)
+	}
+	file, err := os.OpenFile(cfg.Log, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the hooks log: %w", err)
+	}
+	r := &Runner{
+		hooks:    cfg.On,
+		timeout:  cfg.Timeout.Duration,
+		jobs:     make(chan job, queueSize),
+		log:      slog.New(slog.NewTextHandler(file, nil)),
+		byPID:    make(map[int]taskmanager.TaskProcess),
+		busy:     make(map[busyKey]bool),
+		groups:   make(map[int]time.Time),
+		hookPIDs: make(map[int]bool),
+	}
+	for range cfg.MaxConcurrent {
+		go r.work()
+	}
+	return r, nil
+}
+
+// Start runs the hooks of cfg on the events and snapshots of tm in the
+// background. Without hooks it does nothing.
+func Start(cfg config.HooksConfig, tm *taskmanager.TaskManager) error {
+	if len(cfg.On) == 0 {
+		return nil
+	}
+	r, err := NewRunner(cfg)
+	if err != nil {
+		return err
+	}
+	events, _ := tm.SubscribeEvents()
+	snapshots, _ := tm.Subscribe()
+	go r.Run(events, snapshots)
+	logger.Log.Info("hooks started", "hooks", len(cfg.On))
+	return nil
+}
+
+// Run handles events and snapshots until both channels are closed
+func (r *Runner) Run(events <-chan taskmanager.Event, snapshots <-chan taskmanager.Snapshot) {
+	for events != nil || snapshots != nil {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			r.HandleEvent(event)
+		case snapshot, ok := <-snapshots:
+			if !ok {
+				snapshots = nil
+				continue
+			}
+			r.HandleSnapshot(snapshot)
+		}
+	}
+}
+
+// HandleEvent queues the hooks of event whose filters match
+func (r *Runner) HandleEvent(event taskmanager.Event) {
+	if event.Type != taskmanager.EventAction && r.startedByHook(event.Process, event.Type == taskmanager.EventProcessExited) {
+		return
+	}
+
+	for _, hook := range r.hooks {
+		if hook.Event != string(event.Type) {
+			continue
+		}
+		if event.Type == taskmanager.EventAction {
+			if !r.matchesAction(hook, event.Result) {
+				continue
+			}
+		} else if !matches(hook, event.Process) {
+			continue
+		}
+		r.enqueue(hook, newPayload(event))
+	}
+}
+
+// HandleSnapshot queues the workspace_load hooks of workspaces that went
+// above their threshold. A workspace has to drop below it before its hook
+// runs again.
+func (r *Runner) HandleSnapshot(snapshot taskmanager.Snapshot) {
+	// the CPU of quick snapshots is a placeholder, and the quick snapshot
+	// after a kill no longer holds the processes the action event names
+	if !snapshot.Accurate {
+		return
+	}
+	clear(r.byPID)
+	for _, p := range snapshot.Processes {
+		r.byPID[p.PID] = p
+	}
+
+	busy := make(map[busyKey]bool)
+	for i, hook := range r.hooks {
+		if hook.Event != config.HookEventWorkspaceLoad {
+			continue
+		}
+		for _, ws := range workspaceLoads(hook, snapshot.Processes) {
+			key := busyKey{hook: i, workspace: ws.ID}
+			if (hook.CPUAbove == 0 || ws.CPU <= hook.CPUAbove) && (hook.MemAbove == 0 || ws.MEM <= hook.MemAbove) {
+				continue
+			}
+			busy[key] = true
+			if !r.busy[key] {
+				r.enqueue(hook, payload{Event: hook.Event, Time: snapshot.Timestamp, Workspace: &ws})
+			}
+		}
+	}
+	r.busy = busy
+}
+
+// startedByHook reports whether p was started by the command of a hook. The
+// commands run in their own process groups, whose members keep the group when
+// a timeout orphans them.
+func (r *Runner) startedByHook(p taskmanager.TaskProcess, exited bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for pgid, finished := range r.groups {
+		if !finished.IsZero() && time.Since(finished) > groupMemory {
+			delete(r.groups, pgid)
+		}
+	}
+
+	if r.hookPIDs[p.PID] {
+		if exited {
+			delete(r.hookPIDs, p.PID)
+		}
+		return true
+	}
+	_, hook := r.groups[p.PPID]
+	hook = hook || p.PPID == os.Getpid()
+	if pgid, err := unix.Getpgid(p.PID); err == nil && !hook {
+		_, hook = r.groups[pgid]
+	}
+	// an orphan exits with another parent than it started with
+	if hook && !exited {
+		r.hookPIDs[p.PID] = true
+	}
+	return hook
+}
+
+// matches reports whether p passes the filters of hook
+func matches(hook config.HookConfig, p taskmanager.TaskProcess) bool {
+	if hook.Program != "" && p.ProgramName != hook.Program {
+		return false
+	}
+	if hook.Class == "" && hook.Workspace == "" {
+		return true
+	}
+	if p.Meta == nil || p.Meta.Hyprland == nil {
+		return false
+	}
+	w := p.Meta.Hyprland
+	if hook.Workspace != "" && w.Workspace.Name != hook.Workspace && strconv.Itoa(w.Workspace.ID) != hook.Workspace {
+		return false
+	}
+	return hook.Class == "" || strings.EqualFold(w.Class, hook.Class)
+}
+
+// matchesAction reports whether an action acted on a process passing the
+// filters of hook
+func (r *Runner) matchesAction(hook config.HookConfig, result taskmanager.ActionResult) bool {
+	if hook.Program == "" && hook.Class == "" && hook.Workspace == "" {
+		return true
+	}
+	for _, item := range result.Items {
+		if p, ok := r.byPID[item.PID]; ok && matches(hook, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceLoads sums the windows passing the filters of hook by workspace
+func workspaceLoads(hook config.HookConfig, procs []taskmanager.TaskProcess) []workspaceLoad {
+	var loads []workspaceLoad
+	index := make(map[int]int)
+	for _, p := range procs {
+		if p.Meta == nil || p.Meta.Hyprland == nil || !matches(hook, p) {
+			continue
+		}
+		ws := p.Meta.Hyprland.Workspace
+		i, ok := index[ws.ID]
+		if !ok {
+			i = len(loads)
+			index[ws.ID] = i
+			loads = append(loads, workspaceLoad{ID: ws.ID, Name: ws.Name})
+		}
+		loads[i].CPU += p.Metrics.CPU
+		loads[i].MEM += p.Metrics.MEM
+	}
+	return loads
+}
+
+func (r *Runner) enqueue(hook config.HookConfig, p payload) {
+	select {
+	case r.jobs <- job{hook: hook, payload: p}:
+	default:
+		logger.Log.Warn("hook queue is full, event dropped", "event", p.Event, "command", hook.Command)
+		r.log.Warn("queue full, event dropped", "event", p.Event, "command", hook.Command)
+	}
+}
+
+func (r *Runner) work() {
+	for j := range r.jobs {
+		r.run(j)
+	}
+}
+
+// run runs the command of a hook and logs its outcome
+func (r *Runner) run(j job) {
+	input, err := json.Marshal(j.payload)
+	if err != nil {
+		logger.Log.Error("could not encode a hook event", "event", j.payload.Event, "error", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", j.hook.Command)
+	cmd.Env = append(os.Environ(), j.payload.env()...)
+	cmd.Stdin = bytes.NewReader(input)
+	var output limitedBuffer
+	cmd.Stdout, cmd.Stderr = &output, &output
+	// a timeout kills the whole process group, so children of the shell do
+	// not keep running, or keep its output open
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	if err = cmd.Start(); err == nil {
+		r.mu.Lock()
+		r.groups[cmd.Process.Pid] = time.Time{}
+		r.mu.Unlock()
+		err = cmd.Wait()
+		r.mu.Lock()
+		r.groups[cmd.Process.Pid] = time.Now()
+		r.mu.Unlock()
+	}
+	attrs := []any{
+		"event", j.payload.Event,
+		"command", j.hook.Command,
+		"duration", time.Since(start).Round(time.Millisecond),
+		"output", strings.TrimSpace(output.String()),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		r.log.Warn("hook timed out", append(attrs, "timeout", r.timeout)...)
+		logger.Log.Warn("hook timed out", "event", j.payload.Event, "command", j.hook.Command)
+	case errors.Is(err, exec.ErrWaitDelay):
+		r.log.Warn("hook left processes holding its output open", attrs...)
+	case errors.As(err, &exitErr):
+		r.log.Warn("hook failed", append(attrs, "status", exitErr.ExitCode())...)
+		logger.Log.Warn("hook failed", "event", j.payload.Event, "command", j.hook.Command, "status", exitErr.ExitCode())
+	case err != nil:
+		r.log.Error("hook did not run", append(attrs, "error", err)...)
+		logger.Log.Error("hook did not run", "event", j.payload.Event, "command", j.hook.Command, "error", err)
+	default:
+		r.log.Info("hook ran", attrs...)
+	}
+}
+
+// limitedBuffer keeps the first maxOutput bytes written to it
+type limitedBuffer struct {
+	bytes.Buffer
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := maxOutput - b.Len(); len(p) > room {
+		b.Buffer.Write(p[:max(room, 0)])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.Buffer.String() + "\n[output truncated]"
+	}
+	return b.Buffer.String()
+}
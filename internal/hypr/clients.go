@@ -1,20 +1,38 @@
 package hypr
 
 import (
+	"fmt"
+
 	"github.com/paulvinueza30/hyprtask/internal/logger"
 	"github.com/thiagokokada/hyprland-go"
+	"github.com/thiagokokada/hyprland-go/helpers"
 )
 
 type HyprlandClient struct {
-	c *hyprland.RequestClient
+	c   *hyprland.RequestClient
+	err error // why there is no client, e.g. not running under Hyprland
 }
 
+// NewHyprlandClient connects to the Hyprland instance of this session. Outside
+// of Hyprland every request fails instead, so processes are still listed.
 func NewHyprlandClient() *HyprlandClient {
-	c := hyprland.MustClient()
-	return &HyprlandClient{c: c}
+	socket, err := helpers.GetSocket(helpers.RequestSocket)
+	if err != nil {
+		logger.Log.Warn("hyprland is not available", "error", err)
+		return &HyprlandClient{err: err}
+	}
+	return &HyprlandClient{c: hyprland.NewClient(socket)}
+}
+
+// Available reports whether a Hyprland instance was found
+func (c *HyprlandClient) Available() bool {
+	return c.c != nil
 }
 
 func (c *HyprlandClient) GetHyprlandMeta() (map[int]HyprlandMeta, error) {
+	if c.c == nil {
+		return nil, c.err
+	}
 	meta := make(map[int]HyprlandMeta)
 	clients, err := c.c.Clients()
 	if err != nil {
@@ -34,4 +52,50 @@ func (c *HyprlandClient) GetHyprlandMeta() (map[int]HyprlandMeta, error) {
 		}
 	}
 	return meta, nil
-}
\ No newline at end of file
+}
+
+// CloseWindow closes the window of the client with the given PID, like "hyprctl dispatch closewindow pid:<pid>"
+func (c *HyprlandClient) CloseWindow(pid int) error {
+	if c.c == nil {
+		return c.err
+	}
+	if _, err := c.c.Dispatch(fmt.Sprintf("closewindow pid:%d", pid)); err != nil {
+		return fmt.Errorf("close window of pid %d: %w", pid, err)
+	}
+	return nil
+}
+
+// FocusWindow focuses the window of the client with the given PID, like "hyprctl dispatch focuswindow pid:<pid>"
+func (c *HyprlandClient) FocusWindow(pid int) error {
+	if c.c == nil {
+		return c.err
+	}
+	if _, err := c.c.Dispatch(fmt.Sprintf("focuswindow pid:%d", pid)); err != nil {
+		return fmt.Errorf("focus window of pid %d: %w", pid, err)
+	}
+	return nil
+}
+
+// MoveToWorkspace moves the window of the client with the given PID to workspace
+// without focusing it, like "hyprctl dispatch movetoworkspacesilent <workspace>,pid:<pid>"
+func (c *HyprlandClient) MoveToWorkspace(pid int, workspace string) error {
+	if c.c == nil {
+		return c.err
+	}
+	if _, err := c.c.Dispatch(fmt.Sprintf("movetoworkspacesilent %s,pid:%d", workspace, pid)); err != nil {
+		return fmt.Errorf("move window of pid %d to workspace %s: %w", pid, workspace, err)
+	}
+	return nil
+}
+
+// ActiveWorkspace returns the focused workspace, like "hyprctl activeworkspace"
+func (c *HyprlandClient) ActiveWorkspace() (Workspace, error) {
+	if c.c == nil {
+		return Workspace{}, c.err
+	}
+	ws, err := c.c.ActiveWorkspace()
+	if err != nil {
+		return Workspace{}, fmt.Errorf("get active workspace: %w", err)
+	}
+	return Workspace{ID: ws.Id, Name: ws.Name}, nil
+}
@@ -1,13 +1,13 @@
 package hypr
 
 type Workspace struct {
-	ID   int
-	Name string
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 type HyprlandMeta struct {
-	Workspace Workspace
-	Monitor   int
-	Title     string
-	Class     string
-	PID       int
-}
\ No newline at end of file
+	Workspace Workspace `json:"workspace"`
+	Monitor   int       `json:"monitor"`
+	Title     string    `json:"title"`
+	Class     string    `json:"class"`
+	PID       int       `json:"pid"`
+}
@@ -70,3 +70,30 @@ func Init() {
 func (l *CustomLogger) Tui() *slog.Logger {
 	return l.tuiLog
 }
+
+// InitDiscard drops every log record, for commands that must not leave log
+// files in the working directory
+func InitDiscard() {
+	Log = &CustomLogger{
+		Logger: slog.New(slog.DiscardHandler),
+		tuiLog: slog.New(slog.DiscardHandler),
+	}
+}
+
+// InitFile appends every log record to the file at path, for the daemon, which
+// runs without the UI for as long as the session
+func InitFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	handler := slog.NewTextHandler(file, &slog.HandlerOptions{Level: slog.LevelInfo})
+	Log = &CustomLogger{
+		Logger: slog.New(handler),
+		tuiLog: slog.New(slog.DiscardHandler),
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/paulvinueza30/hyprtask/internal/logger"
@@ -17,6 +18,11 @@ type SystemMonitor struct {
 	clockRate   int
 
 	tickDuration time.Duration
+
+	// previous /proc/stat sample used for system-wide CPU deltas
+	prevCPUTotal procfs.CPUStat
+	prevCPU      map[int64]procfs.CPUStat
+	mu           sync.Mutex
 }
 
 func NewSystemMonitor(tickDuration time.Duration) (*SystemMonitor, error) {
@@ -68,8 +74,9 @@ func (m *SystemMonitor) GetMetrics(pid int) (*Metrics, error) {
 	
 	cpuUsage := m.calcCpuUsage(beforeStats.cpuStats, afterStats.cpuStats, totalTime)
 	memUsage := m.calcMemoryUsage(afterStats.memoryStats)
+	ioRead, ioWrite := m.calcIORates(beforeStats.ioStats, afterStats.ioStats)
 	
-	metrics := &Metrics{CPU: cpuUsage, MEM: memUsage, } 
+	metrics := &Metrics{CPU: cpuUsage, MEM: memUsage, IORead: ioRead, IOWrite: ioWrite} 
 	return metrics , nil
 }
 
@@ -116,7 +123,12 @@ func (m *SystemMonitor) getProcStats(pid int) (*ProcStats, error) {
 	cpuStats := CPUStats{cuTime: uint(stat.CUTime), cstTime: uint(stat.CSTime), sTime: stat.STime, uTime: stat.UTime}
 	memStats := MemoryStats{rss: stat.RSS}
 
-	return &ProcStats{cpuStats: cpuStats, memoryStats: memStats}, nil
+	var ioStats IOStats
+	if io, err := proc.IO(); err == nil {
+		ioStats = IOStats{readBytes: io.ReadBytes, writeBytes: io.WriteBytes, available: true}
+	}
+
+	return &ProcStats{cpuStats: cpuStats, memoryStats: memStats, ioStats: ioStats}, nil
 }
 
 func getSystemClockRate() int {
@@ -149,3 +161,18 @@ func (m *SystemMonitor) calcMemoryUsage(memStats MemoryStats) float64 {
 	memoryTotal := uint64(m.totalMemory * 1024) // kB -> b
 	return float64(residentMemorySize) / float64(memoryTotal) * 100.0
 }
+
+// calcIORates returns the storage read and write rates in bytes per second
+func (m *SystemMonitor) calcIORates(before, after IOStats) (float64, float64) {
+	if !before.available || !after.available || m.tickDuration <= 0 {
+		return 0.0, 0.0
+	}
+	// Counters only go backwards if the PID was reused between samples
+	if after.readBytes < before.readBytes || after.writeBytes < before.writeBytes {
+		return 0.0, 0.0
+	}
+	seconds := m.tickDuration.Seconds()
+	read := float64(after.readBytes-before.readBytes) / seconds
+	write := float64(after.writeBytes-before.writeBytes) / seconds
+	return read, write
+}
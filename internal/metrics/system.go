@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/procfs"
+)
+
+// GetSystemUsage returns system-wide CPU, memory, swap, load and uptime figures.
+// CPU usage is the delta since the previous call; the first call reports the
+// average since boot.
+func (m *SystemMonitor) GetSystemUsage() (*SystemUsage, error) {
+	stat, err := m.fs.Stat()
+	if err != nil {
+		return nil, err
+	}
+	memInfo, err := m.fs.Meminfo()
+	if err != nil {
+		return nil, err
+	}
+	loadAvg, err := m.fs.LoadAvg()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	cpu := calcCoreUsage(m.prevCPUTotal, stat.CPUTotal)
+	cores := make([]float64, len(stat.CPU))
+	coreIDs := make([]int, 0, len(stat.CPU))
+	for id := range stat.CPU {
+		coreIDs = append(coreIDs, int(id))
+	}
+	sort.Ints(coreIDs)
+	for i, id := range coreIDs {
+		cores[i] = calcCoreUsage(m.prevCPU[int64(id)], stat.CPU[int64(id)])
+	}
+	m.prevCPUTotal = stat.CPUTotal
+	m.prevCPU = stat.CPU
+	m.mu.Unlock()
+
+	usage := &SystemUsage{
+		CPU:    cpu,
+		Cores:  cores,
+		Load1:  loadAvg.Load1,
+		Load5:  loadAvg.Load5,
+		Load15: loadAvg.Load15,
+		Uptime: time.Since(time.Unix(int64(stat.BootTime), 0)).Truncate(time.Second),
+	}
+
+	if memInfo.MemTotal != nil {
+		usage.MemTotal = *memInfo.MemTotal * 1024 // kB -> b
+		available := uint64(0)
+		if memInfo.MemAvailable != nil {
+			available = *memInfo.MemAvailable * 1024
+		}
+		usage.MemUsed = usage.MemTotal - available
+		usage.MEM = percent(usage.MemUsed, usage.MemTotal)
+	}
+	if memInfo.SwapTotal != nil && memInfo.SwapFree != nil {
+		usage.SwapTotal = *memInfo.SwapTotal * 1024
+		usage.SwapUsed = usage.SwapTotal - *memInfo.SwapFree*1024
+		usage.SWAP = percent(usage.SwapUsed, usage.SwapTotal)
+	}
+
+	return usage, nil
+}
+
+// calcCoreUsage returns the busy percentage between two cumulative CPU stat samples
+func calcCoreUsage(before, after procfs.CPUStat) float64 {
+	idle := (after.Idle + after.Iowait) - (before.Idle + before.Iowait)
+	total := sumCPUStat(after) - sumCPUStat(before)
+	if total <= 0 {
+		return 0.0
+	}
+	return (total - idle) / total * 100.0
+}
+
+func sumCPUStat(s procfs.CPUStat) float64 {
+	// Guest time is already accounted for in User and Nice
+	return s.User + s.Nice + s.System + s.Idle + s.Iowait + s.IRQ + s.SoftIRQ + s.Steal
+}
+
+func percent(used, total uint64) float64 {
+	if total == 0 {
+		return 0.0
+	}
+	return float64(used) / float64(total) * 100.0
+}
@@ -1,24 +1,53 @@
 package metrics
 
-type Metrics struct{
-	CPU float64
-	MEM float64
+import "time"
+
+type Metrics struct {
+	CPU     float64 `json:"cpu"`
+	MEM     float64 `json:"mem"`
+	IORead  float64 `json:"io_read"`  // bytes/s
+	IOWrite float64 `json:"io_write"` // bytes/s
+}
+
+// SystemUsage holds system-wide resource usage
+type SystemUsage struct {
+	CPU   float64   `json:"cpu"`   // aggregate busy percentage across all cores
+	Cores []float64 `json:"cores"` // busy percentage per core, indexed by core ID
+	MEM   float64   `json:"mem"`
+	SWAP  float64   `json:"swap"`
+
+	MemTotal  uint64 `json:"mem_total"`  // bytes
+	MemUsed   uint64 `json:"mem_used"`   // bytes
+	SwapTotal uint64 `json:"swap_total"` // bytes
+	SwapUsed  uint64 `json:"swap_used"`  // bytes
+
+	Load1  float64       `json:"load1"`
+	Load5  float64       `json:"load5"`
+	Load15 float64       `json:"load15"`
+	Uptime time.Duration `json:"uptime_ns"`
 }
+
 type ProcStats struct {
-	cpuStats CPUStats
+	cpuStats    CPUStats
 	memoryStats MemoryStats
+	ioStats     IOStats
 }
 type CPUStats struct {
 	uTime   uint
 	sTime   uint
 	cuTime  uint
 	cstTime uint
-	cpuTime float64 
+	cpuTime float64
 }
 type MemoryStats struct {
 	rss int
-} 
+}
+type IOStats struct {
+	readBytes  uint64
+	writeBytes uint64
+	available  bool // /proc/<pid>/io is only readable for our own processes unless root
+}
 
 var (
 	DEFAULT_METRICS = Metrics{CPU: 0, MEM: 0}
-)
\ No newline at end of file
+)
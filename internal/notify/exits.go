@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+// WatchExits notifies when a process whose program name or window class is
+// listed in watch exits, until events is closed
+func (n *Notifier) WatchExits(events <-chan taskmanager.Event, watch []string) {
+	for event := range events {
+		if event.Type != taskmanager.EventProcessExited {
+			continue
+		}
+		p := event.Process
+		name, ok := watchedName(p, watch)
+		if !ok {
+			continue
+		}
+		err := n.Notify(Notification{
+			Summary: name + " exited",
+			Body:    fmt.Sprintf("%s (%d) is no longer running", p.ProgramName, p.PID),
+			Urgency: UrgencyCritical,
+			Key:     "exit:" + name,
+		})
+		if err != nil {
+			logger.Log.Error("could not notify of an exit", "pid", p.PID, "error", err)
+		}
+	}
+}
+
+// watchedName returns the entry of watch p matches, by program name or window class
+func watchedName(p taskmanager.TaskProcess, watch []string) (string, bool) {
+	for _, name := range watch {
+		if p.ProgramName == name {
+			return name, true
+		}
+		if p.Meta != nil && p.Meta.Hyprland != nil && strings.EqualFold(p.Meta.Hyprland.Class, name) {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,217 @@
+// Package notify shows desktop notifications through the freedesktop
+// Notifications D-Bus interface, which mako, dunst, swaync and the other
+// notification daemons of Hyprland setups implement.
+//
+// Notifications with the same key are de-duplicated: a repeat within the
+// dedupe window is dropped, a later one replaces the earlier notification
+// instead of stacking up. A notification about a window carries a "Focus
+// window" button that focuses it in Hyprland.
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+const (
+	busName    = "org.freedesktop.Notifications"
+	objectPath = dbus.ObjectPath("/org/freedesktop/Notifications")
+	appName    = "hyprtask"
+
+	// how long a key is remembered after its dedupe window, so a repeat
+	// replaces the notification instead of stacking up
+	forget = time.Hour
+
+	// action keys; "default" is invoked by clicking the notification itself
+	actionDefault = "default"
+	actionFocus   = "focus"
+)
+
+// Urgency levels of the specification
+type Urgency byte
+
+const (
+	UrgencyLow Urgency = iota
+	UrgencyNormal
+	UrgencyCritical // stays until dismissed
+)
+
+// Notification is one desktop notification
+type Notification struct {
+	Summary  string
+	Body     string
+	Urgency  Urgency
+	Key      string // notifications with the same key are de-duplicated, empty for none
+	FocusPID int    // process whose window the "Focus window" button focuses, 0 for no button
+}
+
+// Notifier sends notifications. A nil Notifier drops them, so callers need
+// not check whether notifications are available.
+type Notifier struct {
+	conn    *dbus.Conn
+	obj     dbus.BusObject
+	focus   func(pid int) error
+	dedupe  time.Duration
+	actions bool // the server shows buttons
+
+	mu        sync.Mutex
+	sent      map[string]sent // by key
+	focusPIDs map[uint32]int  // notification ID to the PID its button focuses
+}
+
+type sent struct {
+	id uint32
+	at time.Time
+}
+
+// New sends notifications over conn. Repeats within dedupe are dropped, and
+// focus is called with the PID of a notification's window when its button is
+// clicked.
+func New(conn *dbus.Conn, dedupe time.Duration, focus func(pid int) error) (*Notifier, error) {
+	n := &Notifier{
+		conn:      conn,
+		obj:       conn.Object(busName, objectPath),
+		focus:     focus,
+		dedupe:    dedupe,
+		sent:      make(map[string]sent),
+		focusPIDs: make(map[uint32]int),
+	}
+	var caps []string
+	if err := n.obj.Call(busName+".GetCapabilities", 0).Store(&caps); err != nil {
+		return nil, fmt.Errorf("no notification server: %w", err)
+	}
+	for _, c := range caps {
+		n.actions = n.actions || c == "actions"
+	}
+
+	if n.actions {
+		err := conn.AddMatchSignal(dbus.WithMatchObjectPath(objectPath), dbus.WithMatchInterface(busName))
+		if err != nil {
+			return nil, err
+		}
+		signals := make(chan *dbus.Signal, 10)
+		conn.Signal(signals)
+		go n.listen(signals)
+	}
+	return n, nil
+}
+
+// Connect sends notifications over the session bus, which
+// DBUS_SESSION_BUS_ADDRESS names
+func Connect(dedupe time.Duration, focus func(pid int) error) (*Notifier, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("no session bus: %w", err)
+	}
+	n, err := New(conn, dedupe, focus)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return n, nil
+}
+
+// Start connects to the notification server with the settings of cfg, its
+// focus button dispatching to Hyprland, and notifies when watched processes of
+// tm exit. It returns nil without error when notifications are turned off.
+func Start(cfg config.NotificationsConfig, tm *taskmanager.TaskManager) (*Notifier, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	n, err := Connect(cfg.Dedupe.Duration, hypr.NewHyprlandClient().FocusWindow)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Watch) > 0 {
+		events, _ := tm.SubscribeEvents()
+		go n.WatchExits(events, cfg.Watch)
+	}
+	return n, nil
+}
+
+// Notify shows notification, unless one with the same key was shown within
+// the dedupe window
+func (n *Notifier) Notify(notification Notification) error {
+	if n == nil {
+		return nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := time.Now()
+	for key, s := range n.sent {
+		if now.Sub(s.at) >= n.dedupe+forget {
+			delete(n.sent, key)
+		}
+	}
+	var replaces uint32
+	if s, ok := n.sent[notification.Key]; ok && notification.Key != "" {
+		if now.Sub(s.at) < n.dedupe {
+			return nil
+		}
+		replaces = s.id
+	}
+
+	actions := []string{}
+	if notification.FocusPID != 0 && n.actions {
+		actions = append(actions, actionDefault, "Focus window", actionFocus, "Focus window")
+	}
+	hints := map[string]dbus.Variant{"urgency": dbus.MakeVariant(byte(notification.Urgency))}
+
+	var id uint32
+	err := n.obj.Call(busName+".Notify", 0,
+		appName, replaces, "", notification.Summary, notification.Body, actions, hints, int32(-1)).Store(&id)
+	if err != nil {
+		return fmt.Errorf("could not notify: %w", err)
+	}
+	if notification.Key != "" {
+		n.sent[notification.Key] = sent{id: id, at: now}
+	}
+	delete(n.focusPIDs, replaces)
+	if len(actions) > 0 {
+		n.focusPIDs[id] = notification.FocusPID
+	}
+	return nil
+}
+
+// listen focuses windows when their buttons are clicked, until the connection closes
+func (n *Notifier) listen(signals <-chan *dbus.Signal) {
+	for signal := range signals {
+		if len(signal.Body) < 2 {
+			continue
+		}
+		id, _ := signal.Body[0].(uint32)
+		switch signal.Name {
+		case busName + ".ActionInvoked":
+			action, _ := signal.Body[1].(string)
+			n.mu.Lock()
+			pid, ok := n.focusPIDs[id]
+			n.mu.Unlock()
+			if !ok || (action != actionFocus && action != actionDefault) {
+				continue
+			}
+			if err := n.focus(pid); err != nil {
+				logger.Log.Error("could not focus the window of a notification", "pid", pid, "error", err)
+			}
+		case busName + ".NotificationClosed":
+			n.mu.Lock()
+			delete(n.focusPIDs, id)
+			n.mu.Unlock()
+		}
+	}
+}
+
+// Close disconnects from the bus
+func (n *Notifier) Close() error {
+	if n == nil {
+		return nil
+	}
+	return n.conn.Close()
+}
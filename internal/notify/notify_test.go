@@ -0,0 +1,231 @@
+package notify
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitDiscard()
+	os.Exit(m.Run())
+}
+
+// call is one Notify call the fake server received
+type call struct {
+	replaces uint32
+	summary  string
+	actions  []string
+	hints    map[string]dbus.Variant
+}
+
+// fakeServer stands in for a notification daemon on a private bus
+type fakeServer struct {
+	conn *dbus.Conn
+	caps []string
+
+	mu     sync.Mutex
+	calls  []call
+	nextID uint32
+}
+
+func (s *fakeServer) GetCapabilities() ([]string, *dbus.Error) {
+	return s.caps, nil
+}
+
+func (s *fakeServer) Notify(app string, replaces uint32, icon, summary, body string, actions []string, hints map[string]dbus.Variant, timeout int32) (uint32, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call{replaces: replaces, summary: summary, actions: actions, hints: hints})
+	if replaces != 0 {
+		return replaces, nil
+	}
+	s.nextID++
+	return s.nextID, nil
+}
+
+func (s *fakeServer) received() []call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.calls)
+}
+
+func (s *fakeServer) emit(signal string, args ...any) {
+	if err := s.conn.Emit(objectPath, busName+"."+signal, args...); err != nil {
+		panic(err)
+	}
+}
+
+// startBus runs a private session bus and returns its address
+func startBus(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("dbus-daemon"); err != nil {
+		t.Skip("dbus-daemon is not installed")
+	}
+	cmd := exec.Command("dbus-daemon", "--session", "--nofork", "--print-address=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(address)
+}
+
+func connect(t *testing.T, address string) *dbus.Conn {
+	t.Helper()
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// newTestNotifier returns a notifier talking to a fake server with caps, and
+// the PIDs its focus button was clicked for
+func newTestNotifier(t *testing.T, caps []string, dedupe time.Duration) (*Notifier, *fakeServer, <-chan int) {
+	t.Helper()
+	address := startBus(t)
+
+	server := &fakeServer{conn: connect(t, address), caps: caps}
+	if err := server.conn.Export(server, objectPath, busName); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := server.conn.RequestName(busName, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("could not own %s: %v", busName, err)
+	}
+
+	focused := make(chan int, 4)
+	n, err := New(connect(t, address), dedupe, func(pid int) error {
+		focused <- pid
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n, server, focused
+}
+
+func TestNotifyDedupe(t *testing.T) {
+	dedupe := 200 * time.Millisecond
+	n, server, _ := newTestNotifier(t, nil, dedupe)
+
+	notify := func(key string) {
+		t.Helper()
+		if err := n.Notify(Notification{Summary: key, Key: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notify("a")
+	notify("a") // within the window, dropped
+	notify("b") // another key
+	time.Sleep(dedupe)
+	notify("a") // replaces the first
+	notify("")  // no key, never de-duplicated
+	notify("")
+
+	calls := server.received()
+	var got []string
+	for _, c := range calls {
+		got = append(got, c.summary)
+	}
+	if want := []string{"a", "b", "a", "", ""}; !slices.Equal(got, want) {
+		t.Fatalf("server received %q, want %q", got, want)
+	}
+	if calls[0].replaces != 0 || calls[1].replaces != 0 {
+		t.Errorf("first notifications replace %d and %d, want 0", calls[0].replaces, calls[1].replaces)
+	}
+	if calls[2].replaces != 1 {
+		t.Errorf("repeat of a replaces %d, want its first ID 1", calls[2].replaces)
+	}
+}
+
+func TestNotifyUrgency(t *testing.T) {
+	n, server, _ := newTestNotifier(t, nil, time.Minute)
+	for _, urgency := range []Urgency{UrgencyLow, UrgencyNormal, UrgencyCritical} {
+		if err := n.Notify(Notification{Summary: "s", Urgency: urgency}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, c := range server.received() {
+		if got, ok := c.hints["urgency"].Value().(byte); !ok || got != byte(i) {
+			t.Errorf("notification %d has urgency hint %v, want byte %d", i, c.hints["urgency"], i)
+		}
+	}
+}
+
+func TestFocusButton(t *testing.T) {
+	n, server, focused := newTestNotifier(t, []string{"actions", "body"}, time.Minute)
+	if err := n.Notify(Notification{Summary: "busy", FocusPID: 4242}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Notify(Notification{Summary: "no window"}); err != nil {
+		t.Fatal(err)
+	}
+	calls := server.received()
+	if !slices.Contains(calls[0].actions, actionFocus) {
+		t.Fatalf("actions %q have no focus button", calls[0].actions)
+	}
+	if len(calls[1].actions) != 0 {
+		t.Fatalf("notification without a window has actions %q", calls[1].actions)
+	}
+
+	server.emit("ActionInvoked", uint32(1), actionFocus)
+	select {
+	case pid := <-focused:
+		if pid != 4242 {
+			t.Fatalf("focused %d, want 4242", pid)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("clicking the button focused nothing")
+	}
+
+	// a closed notification's button is forgotten, and so is an unknown ID
+	server.emit("NotificationClosed", uint32(1), uint32(2))
+	server.emit("ActionInvoked", uint32(1), actionFocus)
+	server.emit("ActionInvoked", uint32(2), actionFocus)
+	select {
+	case pid := <-focused:
+		t.Fatalf("focused %d after the notification closed", pid)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNoButtonsWithoutActions(t *testing.T) {
+	n, server, _ := newTestNotifier(t, []string{"body"}, time.Minute)
+	if err := n.Notify(Notification{Summary: "busy", FocusPID: 4242}); err != nil {
+		t.Fatal(err)
+	}
+	if actions := server.received()[0].actions; len(actions) != 0 {
+		t.Fatalf("server without actions got %q", actions)
+	}
+}
+
+func TestNilNotifier(t *testing.T) {
+	var n *Notifier
+	if err := n.Notify(Notification{Summary: "dropped"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
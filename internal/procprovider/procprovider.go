@@ -1,10 +1,13 @@
 package procprovider
 
 import (
+	"fmt"
 	"os/user"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/paulvinueza30/hyprtask/internal/logger"
 	"github.com/prometheus/procfs"
@@ -12,13 +15,23 @@ import (
 
 type Proc struct {
 	PID         int
+	PPID        int
 	ProgramName string
 	User        string
 	CommandLine string
+	Exe         string // empty when the link is not readable
+	State       string // single letter as in ps: R, S, D, Z, T, ...
+	Threads     int
+	Nice        int
+	Priority    int
+	StartTime   time.Time
+	TTY         string // "" when the process has no controlling terminal
+	Unit        string // last element of the cgroup path, the systemd unit under systemd
 }
 
 type ProcProvider struct {
 	fs          procfs.FS
+	bootTime    time.Time
 	userCache   map[int]string // UID -> username cache
 	userCacheMu sync.RWMutex
 }
@@ -26,16 +39,26 @@ type ProcProvider struct {
 const (
 	// Worker pool size for parallel process reading
 	workerPoolSize = 50
+
+	// USER_HZ, the unit of start times in /proc/<pid>/stat. Fixed at 100 on Linux.
+	userHZ = 100
 )
 
-func NewProcProvider() ProcProvider {
+func NewProcProvider() *ProcProvider {
 	fs, err := procfs.NewFS("/proc")
 	if err != nil {
 		logger.Log.Error("could not get procfs: " + err.Error())
-		return ProcProvider{}
+		return &ProcProvider{userCache: make(map[int]string)}
+	}
+	var bootTime time.Time
+	if stat, err := fs.Stat(); err == nil {
+		bootTime = time.Unix(int64(stat.BootTime), 0)
+	} else {
+		logger.Log.Warn("could not read boot time, start times will be unavailable", "error", err)
 	}
-	return ProcProvider{
+	return &ProcProvider{
 		fs:        fs,
+		bootTime:  bootTime,
 		userCache: make(map[int]string),
 	}
 }
@@ -103,9 +126,66 @@ func (p *ProcProvider) readProcData(proc procfs.Proc) Proc {
 		procData.User = p.getUsername(uid)
 	}
 
+	if stat, err := proc.Stat(); err == nil {
+		procData.PPID = stat.PPID
+		procData.State = stat.State
+		procData.Threads = stat.NumThreads
+		procData.Nice = stat.Nice
+		procData.Priority = stat.Priority
+		procData.TTY = ttyName(stat.TTY)
+		if !p.bootTime.IsZero() {
+			procData.StartTime = p.bootTime.Add(time.Duration(stat.Starttime) * time.Second / userHZ)
+		}
+	}
+
+	if exe, err := proc.Executable(); err == nil {
+		procData.Exe = exe
+	}
+
+	if cgroups, err := proc.Cgroups(); err == nil {
+		procData.Unit = cgroupUnit(cgroups)
+	}
+
 	return procData
 }
 
+// cgroupUnit names the cgroup of a process by the last element of its path,
+// e.g. "app-firefox@1234.scope". The unified (v2) hierarchy is preferred,
+// then systemd's named v1 hierarchy.
+func cgroupUnit(cgroups []procfs.Cgroup) string {
+	var path string
+	for _, cgroup := range cgroups {
+		if cgroup.HierarchyID == 0 {
+			path = cgroup.Path
+			break
+		}
+		if slices.Contains(cgroup.Controllers, "name=systemd") {
+			path = cgroup.Path
+		}
+	}
+	path = strings.TrimRight(path, "/")
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// ttyName decodes the tty_nr device number from /proc/<pid>/stat
+func ttyName(ttyNr int) string {
+	if ttyNr == 0 {
+		return ""
+	}
+	major := (ttyNr >> 8) & 0xfff
+	minor := (ttyNr & 0xff) | ((ttyNr >> 12) & 0xfff00)
+	switch {
+	case major >= 136 && major <= 143:
+		return fmt.Sprintf("pts/%d", (major-136)*256+minor)
+	case major == 4 && minor < 64:
+		return fmt.Sprintf("tty%d", minor)
+	case major == 4:
+		return fmt.Sprintf("ttyS%d", minor-64)
+	default:
+		return fmt.Sprintf("%d:%d", major, minor)
+	}
+}
+
 func (p *ProcProvider) getUsername(uid int) string {
 	// Check cache first
 	p.userCacheMu.RLock()
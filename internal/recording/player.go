@@ -0,0 +1,209 @@
+package recording
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+const (
+	minSpeed = 0.125
+	maxSpeed = 64
+)
+
+// errReplay fails every action: the processes of a recording are not ours to signal
+var errReplay = errors.New("replaying a recording, actions are disabled")
+
+// Player feeds a recording's snapshots to the view model at the pace they
+// were taken, scaled by the speed
+type Player struct {
+	rec *Recording
+
+	mu     sync.Mutex
+	pos    int // index of the snapshot shown
+	paused bool
+	speed  float64
+	moved  bool          // a control changed pos, which has to be shown
+	wake   chan struct{} // interrupts the wait for the next snapshot
+}
+
+func NewPlayer(rec *Recording) *Player {
+	return &Player{rec: rec, speed: 1, wake: make(chan struct{}, 1)}
+}
+
+// Status describes the playback position
+type Status struct {
+	Index  int // of the snapshot shown
+	Frames int
+	Time   time.Time // when the snapshot shown was taken
+	Paused bool
+	Speed  float64
+}
+
+func (p *Player) Status() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Status{
+		Index:  p.pos,
+		Frames: len(p.rec.Snapshots),
+		Time:   p.rec.Snapshots[p.pos].Timestamp,
+		Paused: p.paused || p.atEnd(),
+		Speed:  p.speed,
+	}
+}
+
+// TogglePause pauses or resumes. Resuming at the end starts over.
+func (p *Player) TogglePause() {
+	p.control(func() {
+		if p.atEnd() {
+			p.pos, p.paused, p.moved = 0, false, true
+			return
+		}
+		p.paused = !p.paused
+	})
+}
+
+// Step pauses and moves n snapshots, back when n is negative
+func (p *Player) Step(n int) {
+	p.control(func() {
+		p.paused = true
+		p.moveTo(p.pos + n)
+	})
+}
+
+// Seek moves to the first snapshot taken d or more after the one shown, or
+// the last one taken d or more before it when d is negative
+func (p *Player) Seek(d time.Duration) {
+	p.control(func() {
+		target := p.rec.Snapshots[p.pos].Timestamp.Add(d)
+		i := p.pos
+		if d > 0 {
+			for i < len(p.rec.Snapshots)-1 && p.rec.Snapshots[i].Timestamp.Before(target) {
+				i++
+			}
+		} else {
+			for i > 0 && p.rec.Snapshots[i].Timestamp.After(target) {
+				i--
+			}
+		}
+		p.moveTo(i)
+	})
+}
+
+// Faster doubles the speed, up to 64x
+func (p *Player) Faster() {
+	p.control(func() { p.speed = min(p.speed*2, maxSpeed) })
+}
+
+// Slower halves the speed, down to 1/8x
+func (p *Player) Slower() {
+	p.control(func() { p.speed = max(p.speed/2, minSpeed) })
+}
+
+// Serve stands in for a task manager: it sends the recording to snapshots
+// until the process exits and fails every action from actions.
+func (p *Player) Serve(snapshots chan<- taskmanager.Snapshot, actions <-chan taskmanager.TaskAction, results chan<- taskmanager.ActionResult) {
+	go rejectActions(actions, results)
+	for {
+		p.mu.Lock()
+		snapshot := p.rec.Snapshots[p.pos]
+		p.moved = false
+		p.mu.Unlock()
+		snapshots <- snapshot
+		p.waitForNext()
+	}
+}
+
+// waitForNext returns once the next snapshot is due or a control moved the
+// position
+func (p *Player) waitForNext() {
+	for {
+		p.mu.Lock()
+		if p.moved {
+			p.mu.Unlock()
+			return
+		}
+		var timer *time.Timer
+		var due <-chan time.Time
+		if !p.paused && !p.atEnd() {
+			timer = time.NewTimer(p.delay())
+			due = timer.C
+		}
+		p.mu.Unlock()
+
+		select {
+		case <-due:
+			p.mu.Lock()
+			if !p.moved {
+				p.pos++
+			}
+			p.mu.Unlock()
+			return
+		case <-p.wake:
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+	}
+}
+
+// delay returns the wait before the snapshot after pos. Callers must hold mu.
+func (p *Player) delay() time.Duration {
+	gap := p.rec.Snapshots[p.pos+1].Timestamp.Sub(p.rec.Snapshots[p.pos].Timestamp)
+	return time.Duration(float64(max(gap, 0)) / p.speed)
+}
+
+// control runs change under the lock and wakes the playback loop
+func (p *Player) control(change func()) {
+	p.mu.Lock()
+	change()
+	p.mu.Unlock()
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// moveTo shows snapshot i, clamped to the recording. Callers must hold mu.
+func (p *Player) moveTo(i int) {
+	i = min(max(i, 0), len(p.rec.Snapshots)-1)
+	if i != p.pos {
+		p.pos, p.moved = i, true
+	}
+}
+
+func (p *Player) atEnd() bool {
+	return p.pos == len(p.rec.Snapshots)-1
+}
+
+func rejectActions(actions <-chan taskmanager.TaskAction, results chan<- taskmanager.ActionResult) {
+	for action := range actions {
+		result := taskmanager.ActionResult{Type: action.Type}
+		for _, pid := range actionPIDs(action) {
+			result.Items = append(result.Items, taskmanager.ItemResult{PID: pid, Err: errReplay})
+		}
+		results <- result
+	}
+}
+
+func actionPIDs(action taskmanager.TaskAction) []int {
+	switch p := action.Payload.(type) {
+	case taskmanager.SignalPayload:
+		return p.PIDs
+	case taskmanager.RenicePayload:
+		return p.PIDs
+	case taskmanager.IOPriorityPayload:
+		return p.PIDs
+	case taskmanager.AffinityPayload:
+		return p.PIDs
+	case taskmanager.CloseWindowPayload:
+		return p.PIDs
+	case taskmanager.FocusWindowPayload:
+		return []int{p.PID}
+	case taskmanager.MoveWindowPayload:
+		return p.PIDs
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package recording
+
+import (
+	"testing"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+func TestPlayerStep(t *testing.T) {
+	p := NewPlayer(loadFixture(t))
+	tests := []struct {
+		step int
+		want int
+	}{
+		{step: -1, want: 0},
+		{step: 2, want: 2},
+		{step: 1, want: 3},
+		{step: 10, want: 5},
+		{step: -3, want: 2},
+		{step: -10, want: 0},
+	}
+	for _, tt := range tests {
+		p.Step(tt.step)
+		status := p.Status()
+		if status.Index != tt.want {
+			t.Fatalf("after Step(%d): index %d, want %d", tt.step, status.Index, tt.want)
+		}
+		if !status.Paused {
+			t.Fatalf("after Step(%d): not paused", tt.step)
+		}
+	}
+}
+
+func TestPlayerSeek(t *testing.T) {
+	p := NewPlayer(loadFixture(t))
+	tests := []struct {
+		seek time.Duration
+		want int
+	}{
+		{seek: -time.Minute, want: 0},
+		{seek: 5 * time.Second, want: 1},
+		{seek: 7 * time.Second, want: 3},  // the first snapshot 7s or more after
+		{seek: -7 * time.Second, want: 1}, // the last snapshot 7s or more before
+		{seek: time.Minute, want: 5},
+		{seek: time.Second, want: 5},
+		{seek: -24 * time.Second, want: 0},
+	}
+	for _, tt := range tests {
+		p.Seek(tt.seek)
+		if got := p.Status().Index; got != tt.want {
+			t.Fatalf("after Seek(%s): index %d, want %d", tt.seek, got, tt.want)
+		}
+	}
+}
+
+func TestPlayerRejectsActions(t *testing.T) {
+	actions := make(chan taskmanager.TaskAction, 1)
+	results := make(chan taskmanager.ActionResult, 1)
+	go rejectActions(actions, results)
+	actions <- taskmanager.TaskAction{Type: taskmanager.TaskActionSignal, Payload: taskmanager.SignalPayload{PIDs: []int{4242, 5001}}}
+	result := <-results
+	close(actions)
+	if result.Failed() != 2 {
+		t.Fatalf("got %+v, want both PIDs failed", result)
+	}
+}
@@ -0,0 +1,135 @@
+// Package recording saves the task manager's snapshots to a file and plays
+// them back into the view model, so a session can be attached to a bug report
+// or used as a fixture without running Hyprland.
+//
+// A recording is gzip compressed JSON lines: a header, then one fully sampled
+// snapshot per line, encoded as schema.Snapshot:
+//
+//	{"format": "hyprtask-recording", "version": 1, "started": "2026-10-19T14:03:00Z"}
+//	{"processes": […], "system": {…}, "timestamp": "2026-10-19T14:03:05Z", "accurate": true}
+package recording
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+const (
+	format  = "hyprtask-recording"
+	Version = 1
+)
+
+type header struct {
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	Started time.Time `json:"started"`
+}
+
+// Recorder writes snapshots to a recording
+type Recorder struct {
+	file   io.WriteCloser
+	gz     *gzip.Writer
+	enc    *json.Encoder
+	frames int
+	last   time.Time // of the latest snapshot recorded
+}
+
+// Create starts a recording at path, replacing any file there
+func Create(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{file: file, gz: gzip.NewWriter(file)}
+	r.enc = json.NewEncoder(r.gz)
+	if err := r.enc.Encode(header{Format: format, Version: Version, Started: time.Now()}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Record appends snapshot. Quick snapshots are skipped, their CPU and I/O are
+// placeholders, and so are snapshots not newer than the last one, which a
+// daemon sends again when the connection to it is restored.
+func (r *Recorder) Record(snapshot taskmanager.Snapshot) error {
+	if !snapshot.Accurate || !snapshot.Timestamp.After(r.last) {
+		return nil
+	}
+	r.frames++
+	r.last = snapshot.Timestamp
+	return r.enc.Encode(snapshot)
+}
+
+// Frames returns how many snapshots have been recorded
+func (r *Recorder) Frames() int {
+	return r.frames
+}
+
+// Close finishes the recording. Without it the file is cut short.
+func (r *Recorder) Close() error {
+	return errors.Join(r.gz.Close(), r.file.Close())
+}
+
+// Recording is a loaded recording
+type Recording struct {
+	Started   time.Time
+	Snapshots []taskmanager.Snapshot // oldest first
+}
+
+// Load reads the recording at path
+func Load(path string) (*Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	rec, err := Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rec, nil
+}
+
+// Read reads a recording from r. A recording cut short, e.g. by a crash, keeps
+// the snapshots before the cut.
+func Read(r io.Reader) (*Recording, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("not a hyprtask recording: %w", err)
+	}
+	dec := json.NewDecoder(gz)
+
+	var h header
+	if err := dec.Decode(&h); err != nil || h.Format != format {
+		return nil, errors.New("not a hyprtask recording")
+	}
+	if h.Version > Version {
+		return nil, fmt.Errorf("recording version %d is newer than this hyprtask supports (%d)", h.Version, Version)
+	}
+
+	rec := &Recording{Started: h.Started}
+	for {
+		var snapshot taskmanager.Snapshot
+		err := dec.Decode(&snapshot)
+		if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rec.Snapshots = append(rec.Snapshots, snapshot)
+	}
+	if len(rec.Snapshots) == 0 {
+		return nil, errors.New("the recording holds no snapshots")
+	}
+	return rec, nil
+}
@@ -0,0 +1,112 @@
+package recording
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+// fixture is a recording of six snapshots taken 5s apart, of a firefox window
+// on workspace 2 whose content process runs in the third to fifth
+const fixture = "testdata/session.rec.gz"
+
+func loadFixture(t *testing.T) *Recording {
+	t.Helper()
+	rec, err := Load(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+func TestLoadFixture(t *testing.T) {
+	rec := loadFixture(t)
+	if len(rec.Snapshots) != 6 {
+		t.Fatalf("got %d snapshots, want 6", len(rec.Snapshots))
+	}
+	for i, snapshot := range rec.Snapshots {
+		if want := rec.Snapshots[0].Timestamp.Add(time.Duration(i) * 5 * time.Second); !snapshot.Timestamp.Equal(want) {
+			t.Errorf("snapshot %d taken at %s, want %s", i, snapshot.Timestamp, want)
+		}
+	}
+	firefox := rec.Snapshots[0].Processes[2]
+	if firefox.PID != 4242 || firefox.Meta == nil || firefox.Meta.Hyprland == nil || firefox.Meta.Hyprland.Class != "firefox" {
+		t.Fatalf("got %+v, want the firefox window", firefox)
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.rec.gz")
+	r, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 10, 19, 14, 3, 0, 0, time.UTC)
+	snapshot := func(seconds int, accurate bool) taskmanager.Snapshot {
+		return taskmanager.Snapshot{
+			Processes: []taskmanager.TaskProcess{{PID: 100 + seconds, ProgramName: "app"}},
+			Timestamp: start.Add(time.Duration(seconds) * time.Second),
+			Accurate:  accurate,
+		}
+	}
+	for _, s := range []taskmanager.Snapshot{
+		snapshot(0, true),
+		snapshot(2, false), // quick, skipped
+		snapshot(5, true),
+		snapshot(5, true), // sent again after a reconnect, skipped
+		snapshot(3, true), // older, skipped
+		snapshot(10, true),
+	} {
+		if err := r.Record(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.Frames() != 3 {
+		t.Fatalf("recorded %d frames, want 3", r.Frames())
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.Snapshots) != 3 {
+		t.Fatalf("read %d snapshots, want 3", len(rec.Snapshots))
+	}
+	for i, seconds := range []int{0, 5, 10} {
+		got := rec.Snapshots[i]
+		if !got.Timestamp.Equal(start.Add(time.Duration(seconds)*time.Second)) || !got.Accurate {
+			t.Errorf("snapshot %d: got %s accurate=%v, want %ds after start", i, got.Timestamp, got.Accurate, seconds)
+		}
+		if len(got.Processes) != 1 || got.Processes[0].PID != 100+seconds {
+			t.Errorf("snapshot %d: got processes %+v", i, got.Processes)
+		}
+	}
+}
+
+func TestReadCutShort(t *testing.T) {
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without the gzip trailer and the end of the last snapshot
+	rec, err := Read(bytes.NewReader(data[:len(data)-40]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(rec.Snapshots); n == 0 || n >= 6 {
+		t.Fatalf("kept %d snapshots, want some but not all 6", n)
+	}
+}
+
+func TestReadRejects(t *testing.T) {
+	if _, err := Read(bytes.NewReader([]byte("{\"format\": \"hyprtask-recording\"}\n"))); err == nil {
+		t.Error("read an uncompressed file")
+	}
+}
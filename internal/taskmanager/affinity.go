@@ -0,0 +1,80 @@
+package taskmanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// maxCPUs is the number of CPUs a unix.CPUSet can describe
+const maxCPUs = 1024
+
+// cpuAffinity returns the CPUs the main thread of pid may run on, in order
+func cpuAffinity(pid int) ([]int, error) {
+	var set unix.CPUSet
+	if err := unix.SchedGetaffinity(pid, &set); err != nil {
+		return nil, err
+	}
+	cpus := make([]int, 0, set.Count())
+	for cpu := 0; cpu < maxCPUs && len(cpus) < cap(cpus); cpu++ {
+		if set.IsSet(cpu) {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
+
+// Thread is one thread of a process
+type Thread struct {
+	TID  int
+	Name string
+}
+
+// Threads lists the threads of pid, for pinning them one by one
+func Threads(pid int) ([]Thread, error) {
+	var threads []Thread
+	err := forEachThread(pid, func(tid int) error {
+		comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%d/comm", pid, tid))
+		if err != nil {
+			return syscall.ESRCH // exited in the meantime
+		}
+		threads = append(threads, Thread{TID: tid, Name: strings.TrimSpace(string(comm))})
+		return nil
+	})
+	return threads, err
+}
+
+// setAffinity pins every thread of pid to cpus
+func setAffinity(pid int, cpus []int) error {
+	set := cpuSet(cpus)
+	return forEachThread(pid, func(tid int) error {
+		return unix.SchedSetaffinity(tid, &set)
+	})
+}
+
+// setThreadAffinity pins the thread tid alone to cpus
+func setThreadAffinity(tid int, cpus []int) error {
+	set := cpuSet(cpus)
+	return unix.SchedSetaffinity(tid, &set)
+}
+
+func cpuSet(cpus []int) unix.CPUSet {
+	var set unix.CPUSet
+	for _, cpu := range cpus {
+		set.Set(cpu)
+	}
+	return set
+}
+
+// explainAffinityError is explainPriorityError plus the case of a set the
+// process is not allowed to use at all, e.g. because of its cgroup
+func explainAffinityError(err error) error {
+	if errors.Is(err, syscall.EINVAL) {
+		return errors.New("none of the chosen CPUs is available to the process")
+	}
+	return explainPriorityError(err, "")
+}
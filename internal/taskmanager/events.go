@@ -0,0 +1,65 @@
+package taskmanager
+
+import (
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+)
+
+// EventType names what happened
+type EventType string
+
+const (
+	EventProcessStarted EventType = "process_started"
+	EventProcessExited  EventType = "process_exited"
+	EventWindowOpened   EventType = "window_opened"
+	EventWindowClosed   EventType = "window_closed"
+	EventAction         EventType = "action"
+)
+
+// Event is a change noticed while polling, or an action taken. Nothing is
+// reported for the processes and windows found by the first poll.
+type Event struct {
+	Type    EventType
+	Time    time.Time
+	Process TaskProcess  // of process and window events; as last seen for exits and closed windows
+	Action  TaskAction   // of action events
+	Result  ActionResult // of action events
+}
+
+// SubscribeEvents returns a channel that receives every event from now on,
+// and a function that ends the subscription. Like snapshots, events are
+// dropped for a subscriber that falls behind.
+func (t *TaskManager) SubscribeEvents() (<-chan Event, func()) {
+	sub := make(chan Event, 64)
+	t.subMu.Lock()
+	t.eventSubscribers[sub] = struct{}{}
+	t.subMu.Unlock()
+	return sub, func() {
+		t.subMu.Lock()
+		delete(t.eventSubscribers, sub)
+		t.subMu.Unlock()
+	}
+}
+
+func (t *TaskManager) sendEvents(events []Event) {
+	t.subMu.Lock()
+	defer t.subMu.Unlock()
+	for _, event := range events {
+		for sub := range t.eventSubscribers {
+			select {
+			case sub <- event:
+			default:
+				logger.Log.Warn("skipped event send - subscriber is not ready", "type", event.Type)
+			}
+		}
+	}
+}
+
+func processEvents(eventType EventType, procs []TaskProcess, now time.Time) []Event {
+	events := make([]Event, len(procs))
+	for i, p := range procs {
+		events[i] = Event{Type: eventType, Time: now, Process: p}
+	}
+	return events
+}
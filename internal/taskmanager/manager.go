@@ -13,25 +13,38 @@ import (
 
 type TaskManager struct {
 	pollingInterval time.Duration
-	systemMonitor   metrics.SystemMonitor
-	procProvider    procprovider.ProcProvider
+	systemMonitor   *metrics.SystemMonitor
+	procProvider    *procprovider.ProcProvider
 	
 	hyprlandClient  *hypr.HyprlandClient
 
 	activeProcesses map[int]TaskProcess // PID to task
+	systemUsage     metrics.SystemUsage
 	mu              sync.RWMutex
 
 	snapshotChan   chan<- Snapshot
 	taskActionChan <-chan TaskAction
+	resultChan     chan<- ActionResult
+
+	subscribers      map[chan Snapshot]struct{}
+	eventSubscribers map[chan Event]struct{}
+	subMu            sync.Mutex
+
+	polled    bool                // after the first poll, whose processes are not reported as started
+	signalled map[int]TaskProcess // removed by signalProcess, their exit is reported by the next poll
+	windows   map[int]TaskProcess // processes with a window by PID, nil before Hyprland was first asked
 }
 
 const (
 	DEBUG_MODE = false
 )
 
-func NewTaskManager(pollInterval time.Duration, snapshotChan chan Snapshot, taskActionChan chan TaskAction) (*TaskManager, error) {
+// NewTaskManager polls every pollInterval, measuring CPU and I/O over sampleWindow.
+// The outcome of every task action is sent on resultChan. snapshotChan may be
+// nil when snapshots are only read through Subscribe.
+func NewTaskManager(pollInterval, sampleWindow time.Duration, snapshotChan chan Snapshot, taskActionChan chan TaskAction, resultChan chan ActionResult) (*TaskManager, error) {
 	procProvider := procprovider.NewProcProvider()
-	systemMonitor, err := metrics.NewSystemMonitor(time.Second * 4)
+	systemMonitor, err := metrics.NewSystemMonitor(sampleWindow)
 	hyprlandClient := hypr.NewHyprlandClient()
 	if err != nil {
 		return nil, err
@@ -40,12 +53,16 @@ func NewTaskManager(pollInterval time.Duration, snapshotChan chan Snapshot, task
 	activeProcesses := make(map[int]TaskProcess)
 	return &TaskManager{
 		pollingInterval: pollInterval, 
-		systemMonitor: *systemMonitor, 
+		systemMonitor: systemMonitor, 
 		procProvider: procProvider, 
 		hyprlandClient: hyprlandClient, 
 		activeProcesses: activeProcesses, 
 		snapshotChan: snapshotChan, 
 		taskActionChan: taskActionChan,
+		resultChan: resultChan,
+		subscribers: make(map[chan Snapshot]struct{}),
+		eventSubscribers: make(map[chan Event]struct{}),
+		signalled: make(map[int]TaskProcess),
 	}, nil
 }
 
@@ -85,23 +102,31 @@ func (t *TaskManager) updateTaskProcesses() {
 		procMap[proc.PID] = proc
 	}
 
-	t.deleteInactiveProcesses(procMap)
+	now := time.Now()
+	exited := t.deleteInactiveProcesses(procMap)
+	t.updateSystemUsage()
 	
 	// Progressive loading: send quick snapshot first, then enrich
-	t.updateActiveProcessesQuick(procMap)
+	started := t.updateActiveProcessesQuick(procMap)
 	
 	// Inject Hyprland metadata immediately (it's fast) so workspaces appear
 	// This must happen after all processes are added to activeProcesses
-	t.injectHyprlandMeta()
+	windowEvents := t.injectHyprlandMeta(now)
 	
 	// Send snapshot with processes and Hyprland metadata
-	t.sendSnapshot()
+	t.sendSnapshot(false)
+
+	events := processEvents(EventProcessExited, exited, now)
+	events = append(events, processEvents(EventProcessStarted, t.processes(started), now)...)
+	t.sendEvents(append(events, windowEvents...))
 	
 	// Enrich with accurate metrics in background (this requires 4-second sleep)
 	go t.enrichProcessesAccurate(procMap)
 }
 
-func (t *TaskManager) deleteInactiveProcesses(procs map[int]procprovider.Proc) {
+// deleteInactiveProcesses forgets the processes that are gone and returns
+// them, as last seen. A PID that was reused counts as gone too.
+func (t *TaskManager) deleteInactiveProcesses(procs map[int]procprovider.Proc) []TaskProcess {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	
@@ -111,22 +136,31 @@ func (t *TaskManager) deleteInactiveProcesses(procs map[int]procprovider.Proc) {
 		snapshot = append(snapshot, pid)
 	}
 
-	deletedCount := 0
+	var exited []TaskProcess
 	for _, pid := range snapshot {
-		if _, ok := procs[pid]; !ok {
-			deletedCount++
+		if proc, ok := procs[pid]; !ok || !proc.StartTime.Equal(t.activeProcesses[pid].StartTime) {
+			exited = append(exited, t.activeProcesses[pid])
 			delete(t.activeProcesses, pid)
 		}
 	}
+	for pid, signalled := range t.signalled {
+		if proc, ok := procs[pid]; !ok || !proc.StartTime.Equal(signalled.StartTime) {
+			exited = append(exited, signalled)
+			delete(t.signalled, pid)
+		}
+	}
 
 	// Only log if there are significant changes
-	if deletedCount > 0 {
-		logger.Log.Info("processes removed", "deleted", deletedCount, "remaining", len(t.activeProcesses))
+	if len(exited) > 0 {
+		logger.Log.Info("processes removed", "deleted", len(exited), "remaining", len(t.activeProcesses))
 	}
+	return exited
 }
 
-// updateActiveProcessesQuick uses quick metrics for immediate display
-func (t *TaskManager) updateActiveProcessesQuick(procs map[int]procprovider.Proc) {
+// updateActiveProcessesQuick uses quick metrics for immediate display. It
+// returns the PIDs of processes that started since the last poll.
+func (t *TaskManager) updateActiveProcessesQuick(procs map[int]procprovider.Proc) []int {
+	var started []int
 	var wg sync.WaitGroup
 	for pid, p := range procs {
 		wg.Add(1)
@@ -137,20 +171,56 @@ func (t *TaskManager) updateActiveProcessesQuick(procs map[int]procprovider.Proc
 				logger.Log.Warn("could not get quick metrics giving default values", "error", err)
 				m = &metrics.DEFAULT_METRICS
 			}
+			// nil when the process exited in the meantime
+			affinity, _ := cpuAffinity(pid)
 
 			t.mu.Lock()
 			defer t.mu.Unlock()
+			if _, survived := t.signalled[pid]; survived {
+				// it survived its signal, it did not start again
+				delete(t.signalled, pid)
+			} else if _, ok := t.activeProcesses[pid]; !ok && t.polled {
+				started = append(started, pid)
+			}
 			t.activeProcesses[pid] = TaskProcess{
 				PID:         pid,
+				PPID:        proc.PPID,
 				ProgramName: proc.ProgramName,
 				User:        proc.User,
 				CommandLine: proc.CommandLine,
+				Exe:         proc.Exe,
+				State:       proc.State,
+				Threads:     proc.Threads,
+				Nice:        proc.Nice,
+				Priority:    proc.Priority,
+				StartTime:   proc.StartTime,
+				TTY:         proc.TTY,
+				Unit:        proc.Unit,
+				Affinity:    affinity,
 				Metrics:     *m,
 				Meta:        &Meta{},
 			}
 		}(pid, p)
 	}
 	wg.Wait()
+
+	t.mu.Lock()
+	t.polled = true
+	t.mu.Unlock()
+	return started
+}
+
+// processes returns the active processes with the given PIDs
+func (t *TaskManager) processes(pids []int) []TaskProcess {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	procs := make([]TaskProcess, 0, len(pids))
+	for _, pid := range pids {
+		if proc, ok := t.activeProcesses[pid]; ok {
+			procs = append(procs, proc)
+		}
+	}
+	return procs
 }
 
 // enrichProcessesAccurate updates processes with accurate metrics in background
@@ -159,7 +229,7 @@ func (t *TaskManager) enrichProcessesAccurate(procs map[int]procprovider.Proc) {
 	t.updateActiveProcessesAccurate(procs)
 	
 	// Send updated snapshot with accurate metrics
-	t.sendSnapshot()
+	t.sendSnapshot(true)
 }
 
 // updateActiveProcessesAccurate uses accurate metrics with sleep delay
@@ -186,7 +256,7 @@ func (t *TaskManager) updateActiveProcessesAccurate(procs map[int]procprovider.P
 	wg.Wait()
 }
 
-func (t *TaskManager) makeSnapshot() Snapshot {
+func (t *TaskManager) makeSnapshot(accurate bool) Snapshot {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
@@ -194,30 +264,76 @@ func (t *TaskManager) makeSnapshot() Snapshot {
 	for _, tp := range t.activeProcesses {
 		procs = append(procs, tp)
 	}
-	return Snapshot{Processes: procs, Timestamp: time.Now()}
+	return Snapshot{Processes: procs, System: t.systemUsage, Timestamp: time.Now(), Accurate: accurate}
 }
 
-func (t *TaskManager) sendSnapshot() {
-	snapshot := t.makeSnapshot()
+func (t *TaskManager) updateSystemUsage() {
+	usage, err := t.systemMonitor.GetSystemUsage()
+	if err != nil {
+		logger.Log.Warn("could not get system usage", "error", err)
+		return
+	}
 
-	select {
-	case t.snapshotChan <- snapshot:
+	t.mu.Lock()
+	t.systemUsage = *usage
+	t.mu.Unlock()
+}
 
-	default:
-		logger.Log.Warn("skipped snapshot send - viewmodel is not ready")
+func (t *TaskManager) sendSnapshot(accurate bool) {
+	snapshot := t.makeSnapshot(accurate)
+
+	if t.snapshotChan != nil {
+		select {
+		case t.snapshotChan <- snapshot:
+
+		default:
+			logger.Log.Warn("skipped snapshot send - viewmodel is not ready")
+		}
+	}
+
+	t.subMu.Lock()
+	defer t.subMu.Unlock()
+	for sub := range t.subscribers {
+		select {
+		case sub <- snapshot:
+		default:
+			logger.Log.Warn("skipped snapshot send - subscriber is not ready")
+		}
+	}
+}
+
+// Subscribe returns a channel that receives every snapshot from now on, and a
+// function that ends the subscription. A subscriber that falls behind misses
+// snapshots instead of holding up polling.
+func (t *TaskManager) Subscribe() (<-chan Snapshot, func()) {
+	sub := make(chan Snapshot, 3)
+	t.subMu.Lock()
+	t.subscribers[sub] = struct{}{}
+	t.subMu.Unlock()
+	return sub, func() {
+		t.subMu.Lock()
+		delete(t.subscribers, sub)
+		t.subMu.Unlock()
 	}
 }
-func (t *TaskManager) injectHyprlandMeta() {
+
+// injectHyprlandMeta adds the windows to their processes and returns the
+// windows that opened and closed since the last poll
+func (t *TaskManager) injectHyprlandMeta(now time.Time) []Event {
+	if !t.hyprlandClient.Available() {
+		return nil
+	}
 	hyprlandMeta, err := t.hyprlandClient.GetHyprlandMeta()
 	if err != nil {
 		logger.Log.Error("could not get hyprland meta: " + err.Error())
-		return
+		return nil
 	}
 	
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	
 	metaCount := 0
+	windows := make(map[int]TaskProcess, len(hyprlandMeta))
 	for pid, meta := range hyprlandMeta {
 		if taskProcess, ok := t.activeProcesses[pid]; ok {
 			// Ensure Meta is initialized
@@ -226,6 +342,7 @@ func (t *TaskManager) injectHyprlandMeta() {
 			}
 			taskProcess.Meta.Hyprland = &meta
 			t.activeProcesses[pid] = taskProcess
+			windows[pid] = taskProcess
 			metaCount++
 		} else {
 			logger.Log.Warn("process not found in active processes", "pid", pid)
@@ -233,37 +350,142 @@ func (t *TaskManager) injectHyprlandMeta() {
 	}
 	
 	logger.Log.Info("injected Hyprland metadata", "totalProcesses", len(t.activeProcesses), "hyprlandProcesses", len(hyprlandMeta), "matched", metaCount)
+
+	var events []Event
+	if t.windows != nil {
+		for pid, proc := range t.windows {
+			if opened, ok := windows[pid]; !ok || !opened.StartTime.Equal(proc.StartTime) {
+				events = append(events, Event{Type: EventWindowClosed, Time: now, Process: proc})
+			}
+		}
+		for pid, proc := range windows {
+			if known, ok := t.windows[pid]; !ok || !known.StartTime.Equal(proc.StartTime) {
+				events = append(events, Event{Type: EventWindowOpened, Time: now, Process: proc})
+			}
+		}
+	}
+	t.windows = windows
+	return events
 }
 
 func (t *TaskManager) handleTaskActions() {
 	for action := range t.taskActionChan {
 		logger.Log.Info("Received task action", "action", action)
-		t.handleTaskAction(action)
-		t.sendSnapshot()
+		t.resultChan <- t.Do(action)
 	}
 }
 
-func (t *TaskManager) handleTaskAction(action TaskAction) {
-	switch action.Type {
-	case TaskActionKill:
-		t.handleKillProcess(action.Payload)
+// Do runs action and returns its outcome, then sends a snapshot showing it
+// and an action event
+func (t *TaskManager) Do(action TaskAction) ActionResult {
+	result := t.handleTaskAction(action)
+	t.sendSnapshot(false)
+	t.sendEvents([]Event{{Type: EventAction, Time: time.Now(), Action: action, Result: result}})
+	return result
+}
+
+func (t *TaskManager) handleTaskAction(action TaskAction) ActionResult {
+	result := ActionResult{Type: action.Type}
+	switch payload := action.Payload.(type) {
+	case SignalPayload:
+		result.Items = forEachPID(payload.PIDs, func(pid int) error {
+			return t.signalProcess(pid, payload.Signal)
+		})
+	case RenicePayload:
+		result.Items = forEachPID(t.expandScope(payload.PIDs, payload.Scope), func(pid int) error {
+			return t.reniceProcess(pid, payload.Nice)
+		})
+	case AffinityPayload:
+		if payload.Scope == ScopeThread {
+			result.Items = forEachPID(payload.PIDs, func(tid int) error {
+				return explainAffinityError(setThreadAffinity(tid, payload.CPUs))
+			})
+			break
+		}
+		result.Items = forEachPID(t.expandScope(payload.PIDs, payload.Scope), func(pid int) error {
+			return t.pinProcess(pid, payload.CPUs)
+		})
+	case IOPriorityPayload:
+		result.Items = forEachPID(t.expandScope(payload.PIDs, payload.Scope), func(pid int) error {
+			var privileged string
+			if payload.Class == IOClassRealtime {
+				privileged = "the realtime class needs root or CAP_SYS_ADMIN"
+			}
+			return explainPriorityError(setIOPriority(pid, payload.Class, payload.Level), privileged)
+		})
+	case CloseWindowPayload:
+		result.Items = forEachPID(payload.PIDs, t.hyprlandClient.CloseWindow)
+	case FocusWindowPayload:
+		result.Items = forEachPID([]int{payload.PID}, t.hyprlandClient.FocusWindow)
+	case MoveWindowPayload:
+		result.Items = forEachPID(payload.PIDs, func(pid int) error {
+			return t.hyprlandClient.MoveToWorkspace(pid, payload.Workspace)
+		})
+	default:
+		logger.Log.Error("task action has an unexpected payload", "type", action.Type, "payload", action.Payload)
+	}
+
+	if failed := result.Failed(); failed > 0 {
+		logger.Log.Warn("task action failed for some processes", "type", action.Type, "failed", failed, "total", len(result.Items))
 	}
+	return result
 }
 
-func (t *TaskManager) handleKillProcess(payload KillProcessPayload) {
-	signal := syscall.SIGTERM
-	if payload.Force {
-		signal = syscall.SIGKILL
+func forEachPID(pids []int, do func(pid int) error) []ItemResult {
+	items := make([]ItemResult, len(pids))
+	for i, pid := range pids {
+		items[i] = ItemResult{PID: pid, Err: do(pid)}
 	}
-	
-	err := syscall.Kill(payload.PID, signal)
-	if err != nil {
-		logger.Log.Error("Failed to kill process", "pid", payload.PID, "signal", signal, "error", err)
-	} else {
-		logger.Log.Info("Successfully killed process", "pid", payload.PID, "signal", signal)
+	return items
+}
+
+func (t *TaskManager) reniceProcess(pid, nice int) error {
+	var privileged string
+	t.mu.RLock()
+	if proc, ok := t.activeProcesses[pid]; ok && nice < proc.Nice {
+		privileged = "raising priority needs root or CAP_SYS_NICE"
+	}
+	t.mu.RUnlock()
+
+	if err := explainPriorityError(renice(pid, nice), privileged); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	if proc, ok := t.activeProcesses[pid]; ok {
+		proc.Nice = nice
+		t.activeProcesses[pid] = proc
+	}
+	t.mu.Unlock()
+	return nil
+}
+
+func (t *TaskManager) pinProcess(pid int, cpus []int) error {
+	if err := explainAffinityError(setAffinity(pid, cpus)); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	if proc, ok := t.activeProcesses[pid]; ok {
+		proc.Affinity = cpus
+		t.activeProcesses[pid] = proc
+	}
+	t.mu.Unlock()
+	return nil
+}
+
+func (t *TaskManager) signalProcess(pid int, signal syscall.Signal) error {
+	if err := syscall.Kill(pid, signal); err != nil {
+		logger.Log.Error("Failed to signal process", "pid", pid, "signal", signal, "error", err)
+		return err
+	}
+	logger.Log.Info("Successfully signalled process", "pid", pid, "signal", signal)
+	if signal == syscall.SIGTERM || signal == syscall.SIGKILL {
 		// Immediately remove from activeProcesses for instant UI feedback
 		t.mu.Lock()
-		delete(t.activeProcesses, payload.PID)
+		if proc, ok := t.activeProcesses[pid]; ok {
+			t.signalled[pid] = proc
+		}
+		delete(t.activeProcesses, pid)
 		t.mu.Unlock()
 	}
+	return nil
 }
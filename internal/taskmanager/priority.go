@@ -0,0 +1,144 @@
+package taskmanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// Scope widens the processes a priority action applies to
+type Scope int
+
+const (
+	ScopeProcess Scope = iota
+	ScopeTree          // the process and all of its descendants
+	ScopeThread        // the PIDs are thread IDs, each thread is changed alone; affinity only
+)
+
+// IOClass is an I/O scheduling class, numbered as IOPRIO_CLASS_* in the kernel
+type IOClass int
+
+const (
+	IOClassNone IOClass = iota
+	IOClassRealtime
+	IOClassBestEffort
+	IOClassIdle
+)
+
+func (c IOClass) String() string {
+	switch c {
+	case IOClassRealtime:
+		return "realtime"
+	case IOClassBestEffort:
+		return "best-effort"
+	case IOClassIdle:
+		return "idle"
+	default:
+		return "none"
+	}
+}
+
+const (
+	MinNice          = -20
+	MaxNice          = 19
+	MaxIOLevel       = 7 // levels run from 0 (highest) to 7 within a class
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+)
+
+// expandScope returns pids, followed by their descendants for ScopeTree,
+// without duplicates
+func (t *TaskManager) expandScope(pids []int, scope Scope) []int {
+	if scope != ScopeTree {
+		return pids
+	}
+
+	t.mu.RLock()
+	children := make(map[int][]int)
+	for pid, proc := range t.activeProcesses {
+		children[proc.PPID] = append(children[proc.PPID], pid)
+	}
+	t.mu.RUnlock()
+
+	seen := make(map[int]bool)
+	var expanded []int
+	queue := append([]int{}, pids...)
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		expanded = append(expanded, pid)
+		queue = append(queue, children[pid]...)
+	}
+	return expanded
+}
+
+// renice sets the nice value of every thread of pid. setpriority only
+// changes the thread whose ID it is given.
+func renice(pid, nice int) error {
+	return forEachThread(pid, func(tid int) error {
+		return syscall.Setpriority(syscall.PRIO_PROCESS, tid, nice)
+	})
+}
+
+// setIOPriority sets the I/O class and level of every thread of pid
+func setIOPriority(pid int, class IOClass, level int) error {
+	if class == IOClassIdle {
+		// the idle class has no levels
+		level = 0
+	}
+	prio := uintptr(class)<<ioprioClassShift | uintptr(level)
+	return forEachThread(pid, func(tid int) error {
+		_, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(tid), prio)
+		if errno != 0 {
+			return errno
+		}
+		return nil
+	})
+}
+
+// forEachThread runs do for each thread of pid and returns the first error.
+// Threads that exit in the meantime are skipped.
+func forEachThread(pid int, do func(tid int) error) error {
+	entries, err := os.ReadDir(fmt.Sprintf("/proc/%d/task", pid))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return syscall.ESRCH
+		}
+		return err
+	}
+	for _, entry := range entries {
+		tid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		if err := do(tid); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return err
+		}
+	}
+	return nil
+}
+
+// explainPriorityError turns the errno of a failed priority change into a
+// message that says what is missing. privileged names the privilege the change
+// needed, if any, e.g. "raising priority needs root or CAP_SYS_NICE".
+func explainPriorityError(err error, privileged string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, syscall.EPERM), errors.Is(err, syscall.EACCES):
+		if privileged != "" {
+			return fmt.Errorf("permission denied: %s", privileged)
+		}
+		return errors.New("permission denied: the process belongs to another user")
+	case errors.Is(err, syscall.ESRCH):
+		return errors.New("process has exited")
+	default:
+		return err
+	}
+}
@@ -0,0 +1,29 @@
+package taskmanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// ParseSignal accepts names with or without the SIG prefix, in any case, and numbers
+func ParseSignal(name string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(name); err == nil {
+		if unix.SignalName(syscall.Signal(n)) == "" {
+			return 0, fmt.Errorf("unknown signal %d", n)
+		}
+		return syscall.Signal(n), nil
+	}
+	upper := strings.ToUpper(name)
+	if !strings.HasPrefix(upper, "SIG") {
+		upper = "SIG" + upper
+	}
+	signal := unix.SignalNum(upper)
+	if signal == 0 {
+		return 0, fmt.Errorf("unknown signal %q", name)
+	}
+	return signal, nil
+}
@@ -1,6 +1,7 @@
 package taskmanager
 
 import (
+	"syscall"
 	"time"
 
 	"github.com/paulvinueza30/hyprtask/internal/hypr"
@@ -8,35 +9,148 @@ import (
 )
 
 type Meta struct {
-	Hyprland *hypr.HyprlandMeta
+	Hyprland *hypr.HyprlandMeta `json:"hyprland"`
 }
 
 type TaskProcess struct {
-	PID         int
-	ProgramName string
-	User        string
-	CommandLine string
-	Meta        *Meta
-	Metrics     metrics.Metrics
+	PID         int             `json:"pid"`
+	PPID        int             `json:"ppid"`
+	ProgramName string          `json:"program"`
+	User        string          `json:"user"`
+	CommandLine string          `json:"command_line"`
+	Exe         string          `json:"exe"`
+	State       string          `json:"state"` // single letter as in ps: R, S, D, Z, T, ...
+	Threads     int             `json:"threads"`
+	Nice        int             `json:"nice"`
+	Priority    int             `json:"priority"`
+	StartTime   time.Time       `json:"start_time"`
+	TTY         string          `json:"tty"`
+	Unit        string          `json:"unit"`     // cgroup, named after its systemd unit under systemd
+	Affinity    []int           `json:"affinity"` // CPUs the process may run on, nil when unknown
+	Meta        *Meta           `json:"meta"`
+	Metrics     metrics.Metrics `json:"metrics"`
 }
 
+// Process states as reported in /proc/<pid>/stat
+const (
+	StateRunning         = "R"
+	StateSleeping        = "S"
+	StateUninterruptible = "D"
+	StateZombie          = "Z"
+	StateStopped         = "T"
+	StateTracingStop     = "t"
+	StateIdle            = "I"
+)
+
 type Snapshot struct {
-	Processes []TaskProcess
-	Timestamp time.Time
+	Processes []TaskProcess       `json:"processes"`
+	System    metrics.SystemUsage `json:"system"`
+	Timestamp time.Time           `json:"timestamp"`
+	Accurate  bool                `json:"accurate"` // true once CPU/IO have been sampled over the full window, false for quick snapshots
 }
 
 type TaskAction struct {
 	Type    TaskActionType
-	Payload KillProcessPayload
+	Payload any // the payload type matching Type
 }
 
 type TaskActionType int
 
 const (
-	TaskActionKill TaskActionType = iota
+	TaskActionSignal      TaskActionType = iota // SignalPayload
+	TaskActionRenice                            // RenicePayload
+	TaskActionCloseWindow                       // CloseWindowPayload
+	TaskActionIOPriority                        // IOPriorityPayload
+	TaskActionAffinity                          // AffinityPayload
+	TaskActionFocusWindow                       // FocusWindowPayload
+	TaskActionMoveWindow                        // MoveWindowPayload
 )
 
-type KillProcessPayload struct {
-	PID   int
-	Force bool // true for SIGKILL, false for SIGTERM
+func (t TaskActionType) String() string {
+	switch t {
+	case TaskActionSignal:
+		return "signal"
+	case TaskActionRenice:
+		return "renice"
+	case TaskActionCloseWindow:
+		return "close window"
+	case TaskActionIOPriority:
+		return "io priority"
+	case TaskActionAffinity:
+		return "cpu affinity"
+	case TaskActionFocusWindow:
+		return "focus window"
+	case TaskActionMoveWindow:
+		return "move window"
+	default:
+		return "unknown"
+	}
+}
+
+// SignalPayload sends one signal to every listed process
+type SignalPayload struct {
+	PIDs   []int
+	Signal syscall.Signal
+}
+
+// RenicePayload sets the nice value of every listed process
+type RenicePayload struct {
+	PIDs  []int
+	Scope Scope
+	Nice  int
+}
+
+// IOPriorityPayload sets the I/O scheduling class and level of every listed process
+type IOPriorityPayload struct {
+	PIDs  []int
+	Scope Scope
+	Class IOClass
+	Level int // 0 (highest) to MaxIOLevel, ignored for IOClassIdle
+}
+
+// AffinityPayload pins every thread of the listed processes to CPUs, or with
+// ScopeThread only the listed threads
+type AffinityPayload struct {
+	PIDs  []int
+	Scope Scope
+	CPUs  []int
+}
+
+// CloseWindowPayload asks Hyprland to close the windows of the listed processes
+type CloseWindowPayload struct {
+	PIDs []int
+}
+
+// FocusWindowPayload asks Hyprland to focus the window of a process
+type FocusWindowPayload struct {
+	PID int
+}
+
+// MoveWindowPayload asks Hyprland to move the windows of the listed processes
+// to a workspace, without following them
+type MoveWindowPayload struct {
+	PIDs      []int
+	Workspace string // as in hyprctl dispatch: an ID, a name prefixed by "name:", ...
+}
+
+// ActionResult reports the outcome of a task action, one item per process
+type ActionResult struct {
+	Type  TaskActionType
+	Items []ItemResult
+}
+
+type ItemResult struct {
+	PID int
+	Err error // nil on success
+}
+
+// Failed counts the items that returned an error
+func (r ActionResult) Failed() int {
+	failed := 0
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed++
+		}
+	}
+	return failed
 }
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Braille cells are 2 dots wide and 4 dots tall.
+// dotBits[y][x] is the bit for the dot at column x, row y within a cell.
+var dotBits = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+const brailleBase = 0x2800
+
+// Canvas is a braille dot canvas where every cell remembers which series drew
+// into it last so overlaid series keep their own colors.
+type Canvas struct {
+	width, height int // in cells
+	dots          [][]rune
+	owner         [][]int // series index per cell, -1 when empty
+}
+
+func NewCanvas(width, height int) *Canvas {
+	c := &Canvas{width: width, height: height}
+	c.dots = make([][]rune, height)
+	c.owner = make([][]int, height)
+	for y := range height {
+		c.dots[y] = make([]rune, width)
+		c.owner[y] = make([]int, width)
+		for x := range width {
+			c.owner[y][x] = -1
+		}
+	}
+	return c
+}
+
+// DotWidth and DotHeight are the canvas resolution in dots
+func (c *Canvas) DotWidth() int  { return c.width * 2 }
+func (c *Canvas) DotHeight() int { return c.height * 4 }
+
+// Set lights the dot at (x, y), with y = 0 at the top
+func (c *Canvas) Set(x, y, series int) {
+	if x < 0 || y < 0 || x >= c.DotWidth() || y >= c.DotHeight() {
+		return
+	}
+	cx, cy := x/2, y/4
+	c.dots[cy][cx] |= dotBits[y%4][x%2]
+	c.owner[cy][cx] = series
+}
+
+// Line draws a straight line between two dots using Bresenham's algorithm
+func (c *Canvas) Line(x0, y0, x1, y1, series int) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.Set(x0, y0, series)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// Render returns the canvas rows, coloring each cell with the style of its series
+func (c *Canvas) Render(styles []lipgloss.Style) []string {
+	lines := make([]string, c.height)
+	for y := range c.height {
+		var b strings.Builder
+		for x := range c.width {
+			if c.owner[y][x] < 0 {
+				b.WriteRune(' ')
+				continue
+			}
+			cell := string(brailleBase + c.dots[y][x])
+			if owner := c.owner[y][x]; owner < len(styles) {
+				cell = styles[owner].Render(cell)
+			}
+			b.WriteString(cell)
+		}
+		lines[y] = b.String()
+	}
+	return lines
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
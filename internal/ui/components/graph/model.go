@@ -0,0 +1,170 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+const (
+	yLabelWidth = 9
+	minYMax     = 1.0
+)
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+type Series struct {
+	Name   string
+	Points []Point // oldest first
+	Style  lipgloss.Style
+}
+
+// Graph plots one or more series sharing a y axis over a sliding time window
+type Graph struct {
+	Title       string
+	Series      []Series
+	Window      time.Duration
+	Now         time.Time
+	FormatValue func(float64) string
+
+	width  int
+	height int
+}
+
+func NewGraph(title string, formatValue func(float64) string) *Graph {
+	return &Graph{
+		Title:       title,
+		FormatValue: formatValue,
+	}
+}
+
+func (g *Graph) Init() tea.Cmd {
+	return nil
+}
+
+func (g *Graph) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		g.SetSize(msg.Width, msg.Height)
+	}
+	return g, nil
+}
+
+func (g *Graph) SetSize(width, height int) {
+	g.width = width
+	g.height = height
+}
+
+func (g *Graph) View() string {
+	t := theme.Get().Graph
+
+	// title, x axis labels and legend take one line each
+	plotHeight := g.height - 3
+	plotWidth := g.width - yLabelWidth - 1
+	if plotHeight < 2 || plotWidth < 4 || g.Window <= 0 {
+		return ""
+	}
+
+	yMax := g.visibleMax() * 1.1
+	canvas := NewCanvas(plotWidth, plotHeight)
+	start := g.Now.Add(-g.Window)
+	styles := make([]lipgloss.Style, len(g.Series))
+
+	for i, series := range g.Series {
+		styles[i] = series.Style
+		prevX, prevY, havePrev := 0, 0, false
+		for _, p := range series.Points {
+			if p.Time.Before(start) {
+				continue
+			}
+			x := int(float64(p.Time.Sub(start)) / float64(g.Window) * float64(canvas.DotWidth()-1))
+			y := canvas.DotHeight() - 1 - int(p.Value/yMax*float64(canvas.DotHeight()-1))
+			if havePrev {
+				canvas.Line(prevX, prevY, x, y, i)
+			} else {
+				canvas.Set(x, y, i)
+			}
+			prevX, prevY, havePrev = x, y, true
+		}
+	}
+
+	plotLines := canvas.Render(styles)
+	rows := make([]string, 0, g.height)
+	rows = append(rows, t.Title.Render(g.Title))
+	for i, line := range plotLines {
+		rows = append(rows, g.yLabel(i, len(plotLines), yMax)+t.Axis.Render("│")+line)
+	}
+	rows = append(rows, g.xAxis(plotWidth))
+	rows = append(rows, g.legend())
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// visibleMax returns the largest value inside the current window
+func (g *Graph) visibleMax() float64 {
+	start := g.Now.Add(-g.Window)
+	peak := minYMax
+	for _, series := range g.Series {
+		for _, p := range series.Points {
+			if !p.Time.Before(start) {
+				peak = max(peak, p.Value)
+			}
+		}
+	}
+	return peak
+}
+
+func (g *Graph) yLabel(row, rows int, yMax float64) string {
+	var label string
+	switch row {
+	case 0:
+		label = g.FormatValue(yMax)
+	case rows / 2:
+		label = g.FormatValue(yMax / 2)
+	case rows - 1:
+		label = g.FormatValue(0)
+	}
+	return theme.Get().Graph.Axis.Width(yLabelWidth).Align(lipgloss.Right).Render(label)
+}
+
+func (g *Graph) xAxis(plotWidth int) string {
+	left := "-" + formatWindow(g.Window)
+	mid := "-" + formatWindow(g.Window/2)
+	right := "now"
+	gap := plotWidth - len(left) - len(mid) - len(right)
+	if gap < 2 {
+		return strings.Repeat(" ", yLabelWidth+1) + left + strings.Repeat(" ", max(plotWidth-len(left)-len(right), 1)) + right
+	}
+	leftGap := gap / 2
+	axis := left + strings.Repeat(" ", leftGap) + mid + strings.Repeat(" ", gap-leftGap) + right
+	return strings.Repeat(" ", yLabelWidth+1) + theme.Get().Graph.Axis.Render(axis)
+}
+
+func (g *Graph) legend() string {
+	items := make([]string, 0, len(g.Series))
+	for _, series := range g.Series {
+		current := "-"
+		if n := len(series.Points); n > 0 {
+			current = g.FormatValue(series.Points[n-1].Value)
+		}
+		items = append(items, series.Style.Render("■ ")+theme.Get().Graph.Legend.Render(fmt.Sprintf("%s %s", series.Name, current)))
+	}
+	return strings.Repeat(" ", yLabelWidth+1) + strings.Join(items, "   ")
+}
+
+func formatWindow(d time.Duration) string {
+	if d >= time.Minute && d%time.Minute == 0 {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	if d >= time.Minute {
+		return fmt.Sprintf("%.1fm", d.Minutes())
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
@@ -0,0 +1,128 @@
+package helpoverlay
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
+	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+const (
+	columnGap = 4
+	// border, padding, title and hint lines around the sections
+	chromeHeight = 8
+)
+
+// HelpOverlay lists every binding of a screen, generated from the key map so
+// it follows the user's remapping
+type HelpOverlay struct {
+	show   bool
+	screen screens.ScreenType
+	width  int
+	height int
+}
+
+func NewHelpOverlay() *HelpOverlay {
+	return &HelpOverlay{}
+}
+
+func (h *HelpOverlay) Init() tea.Cmd {
+	return nil
+}
+
+func (h *HelpOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h.SetSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		// any key closes the overlay
+		h.show = false
+	case tea.MouseMsg:
+		if msg.Action == tea.MouseActionPress && !tea.MouseEvent(msg).IsWheel() {
+			h.show = false
+		}
+	}
+	return h, nil
+}
+
+func (h *HelpOverlay) View() string {
+	if !h.show {
+		return ""
+	}
+	t := theme.Get()
+
+	var blocks []string
+	for _, section := range keymap.Get().FullHelp(h.screen) {
+		blocks = append(blocks, renderSection(section))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		t.Dialog.Title.Render(fmt.Sprintf("Keys — %s", h.screen)),
+		"",
+		layoutColumns(blocks, h.height-chromeHeight),
+		"",
+		t.Dialog.Hint.Render("press any key to close"),
+	)
+	return lipgloss.Place(h.width, h.height, lipgloss.Center, lipgloss.Center, t.Dialog.Box.Render(content))
+}
+
+func (h *HelpOverlay) SetSize(width, height int) {
+	h.width = width
+	h.height = height
+}
+
+// Show opens the overlay with the bindings of screen
+func (h *HelpOverlay) Show(screen screens.ScreenType) {
+	h.show = true
+	h.screen = screen
+}
+
+func (h *HelpOverlay) Visible() bool {
+	return h.show
+}
+
+func renderSection(section keymap.HelpSection) string {
+	t := theme.Get()
+	keyWidth := 0
+	for _, entry := range section.Entries {
+		keyWidth = max(keyWidth, lipgloss.Width(entry.Keys))
+	}
+
+	lines := []string{t.Dialog.Title.Render(string(section.Category))}
+	for _, entry := range section.Entries {
+		lines = append(lines, t.Help.Key.Width(keyWidth).Render(entry.Keys)+"  "+t.Help.Desc.Render(entry.Desc))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// layoutColumns stacks the blocks top to bottom, starting a new column when
+// the next block would exceed maxHeight
+func layoutColumns(blocks []string, maxHeight int) string {
+	var columns []string
+	var current []string
+	height := 0
+	for _, block := range blocks {
+		blockHeight := lipgloss.Height(block) + 1 // blank line between blocks
+		if len(current) > 0 && height+blockHeight > maxHeight {
+			columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, current...))
+			current, height = nil, 0
+		}
+		if len(current) > 0 {
+			current = append(current, "")
+		}
+		current = append(current, block)
+		height += blockHeight
+	}
+	if len(current) > 0 {
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, current...))
+	}
+
+	for i := 1; i < len(columns); i++ {
+		columns[i] = lipgloss.NewStyle().PaddingLeft(columnGap).Render(columns[i])
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
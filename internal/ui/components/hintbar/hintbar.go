@@ -0,0 +1,38 @@
+package hintbar
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+const separator = " • "
+
+// Render lays out bindings on one line of at most width cells. Bindings that
+// do not fit are dropped from the end and replaced by an ellipsis.
+func Render(bindings []key.Binding, width int) string {
+	t := theme.Get().Help
+	var b strings.Builder
+	used := 0
+	for _, binding := range bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		item := t.Key.Render(binding.Help().Key) + " " + t.Desc.Render(binding.Help().Desc)
+		if used > 0 {
+			item = t.Desc.Render(separator) + item
+		}
+		w := lipgloss.Width(item)
+		if width > 0 && used+w > width {
+			if used+2 <= width {
+				b.WriteString(t.Desc.Render(" …"))
+			}
+			break
+		}
+		b.WriteString(item)
+		used += w
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package sparkline
+
+import "strings"
+
+var levels = []rune("▁▂▃▄▅▆▇█")
+
+// Render draws values as a single-line sparkline exactly width cells wide.
+// When there are more values than cells, each cell shows the maximum of its
+// bucket so short spikes are never averaged away. Values are scaled against
+// maxValue, or against the series peak when maxValue is 0.
+func Render(values []float64, width int, maxValue float64) string {
+	if width <= 0 {
+		return ""
+	}
+	points := downsample(values, width)
+
+	if maxValue <= 0 {
+		for _, v := range points {
+			maxValue = max(maxValue, v)
+		}
+	}
+
+	var b strings.Builder
+	// Right-align so the newest sample is always in the last cell
+	b.WriteString(strings.Repeat(" ", width-len(points)))
+	for _, v := range points {
+		b.WriteRune(level(v, maxValue))
+	}
+	return b.String()
+}
+
+func level(v, maxValue float64) rune {
+	if maxValue <= 0 || v <= 0 {
+		return levels[0]
+	}
+	idx := int(v / maxValue * float64(len(levels)-1))
+	return levels[min(max(idx, 0), len(levels)-1)]
+}
+
+func downsample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+	out := make([]float64, width)
+	for i := range width {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+		peak := values[start]
+		for _, v := range values[start:end] {
+			peak = max(peak, v)
+		}
+		out[i] = peak
+	}
+	return out
+}
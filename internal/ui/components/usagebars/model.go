@@ -1 +1,162 @@
 package usagebars
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/metrics"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+const (
+	maxCoreRows  = 2  // per-core bars wrap into at most this many rows
+	minCellWidth = 14 // narrower cells fall back to a single aggregate CPU bar
+	labelWidth   = 4
+	valueWidth   = 6
+)
+
+type UsageBars struct {
+	Usage       metrics.SystemUsage
+	highlighted map[int]bool // cores drawn with the pinned style
+	width       int
+}
+
+type UpdateUsageMsg struct {
+	Usage metrics.SystemUsage
+}
+
+// HighlightCoresMsg marks the cores a process is pinned to, nil clears them
+type HighlightCoresMsg struct {
+	Cores []int
+}
+
+func NewUsageBars() *UsageBars {
+	return &UsageBars{}
+}
+
+func (ub *UsageBars) Init() tea.Cmd {
+	return nil
+}
+
+func (ub *UsageBars) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case UpdateUsageMsg:
+		ub.Usage = msg.Usage
+	case HighlightCoresMsg:
+		ub.highlighted = make(map[int]bool, len(msg.Cores))
+		for _, core := range msg.Cores {
+			ub.highlighted[core] = true
+		}
+	case tea.WindowSizeMsg:
+		ub.width = msg.Width
+	}
+	return ub, nil
+}
+
+func (ub *UsageBars) View() string {
+	if ub.width <= 0 {
+		return ""
+	}
+
+	lines := ub.coreLines()
+	lines = append(lines, ub.memoryLine(), ub.systemLine())
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (ub *UsageBars) coreLines() []string {
+	cores := ub.Usage.Cores
+	if len(cores) == 0 {
+		return []string{ub.renderCell("CPU", ub.Usage.CPU, fmt.Sprintf("%5.1f%%", ub.Usage.CPU), ub.width)}
+	}
+
+	cols := (len(cores) + maxCoreRows - 1) / maxCoreRows
+	cellWidth := ub.width / cols
+	if cellWidth < minCellWidth {
+		return []string{ub.renderCell("CPU", ub.Usage.CPU, fmt.Sprintf("%5.1f%%", ub.Usage.CPU), ub.width)}
+	}
+
+	rows := (len(cores) + cols - 1) / cols
+	lines := make([]string, 0, rows)
+	for r := range rows {
+		cells := make([]string, 0, cols)
+		for c := range cols {
+			// Fill column-major so cores read top-to-bottom like btop
+			id := c*rows + r
+			if id >= len(cores) {
+				break
+			}
+			label, value := fmt.Sprintf("%d", id), fmt.Sprintf("%5.1f%%", cores[id])
+			if ub.highlighted[id] {
+				t := theme.Get().UsageBars
+				cells = append(cells, renderStyledCell(t.Pinned, t.PinnedBar, label, cores[id], value, cellWidth))
+			} else {
+				cells = append(cells, ub.renderCell(label, cores[id], value, cellWidth))
+			}
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	return lines
+}
+
+func (ub *UsageBars) memoryLine() string {
+	u := ub.Usage
+	half := ub.width / 2
+	mem := ub.renderCell("Mem", u.MEM, formatBytes(u.MemUsed)+"/"+formatBytes(u.MemTotal), half)
+	swp := ub.renderCell("Swp", u.SWAP, formatBytes(u.SwapUsed)+"/"+formatBytes(u.SwapTotal), ub.width-half)
+	return lipgloss.JoinHorizontal(lipgloss.Top, mem, swp)
+}
+
+func (ub *UsageBars) systemLine() string {
+	u := ub.Usage
+	text := fmt.Sprintf("Load %.2f %.2f %.2f   Up %s", u.Load1, u.Load5, u.Load15, formatUptime(u.Uptime))
+	return theme.Get().UsageBars.Text.Width(ub.width).Align(lipgloss.Center).Render(text)
+}
+
+// renderCell renders "label [bar] value" padded to exactly width cells
+func (ub *UsageBars) renderCell(label string, pct float64, value string, width int) string {
+	t := theme.Get().UsageBars
+	return renderStyledCell(t.Text, t.Bar, label, pct, value, width)
+}
+
+func renderStyledCell(text, barStyle lipgloss.Style, label string, pct float64, value string, width int) string {
+	t := theme.Get().UsageBars
+	valueLen := max(valueWidth, lipgloss.Width(value))
+	barWidth := width - labelWidth - valueLen - 3 // spaces around bar and trailing gap
+	if barWidth < 1 {
+		return text.Width(width).Render(label + " " + value)
+	}
+
+	pct = min(max(pct, 0), 100)
+	filled := int(float64(barWidth) * pct / 100.0)
+	bar := barStyle.Render(strings.Repeat(t.BarFill, filled) + strings.Repeat(" ", barWidth-filled))
+
+	labelText := text.Width(labelWidth).Align(lipgloss.Right).Render(label)
+	valueText := text.Width(valueLen).Align(lipgloss.Right).Render(value)
+	return lipgloss.NewStyle().Width(width).Render(labelText + " " + bar + " " + valueText)
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+func formatUptime(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
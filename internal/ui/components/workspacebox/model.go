@@ -6,15 +6,21 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/sparkline"
 	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
 )
 
+const (
+	graphWidth = 20
+)
+
 type WorkspaceBox struct {
 	ID          int
 	Name        string
 	WindowCount int
 	CPUUsage    float64
 	MemUsage    float64
+	CPUHistory  []float64 // oldest first
 	IsSelected  bool
 }
 
@@ -69,8 +75,9 @@ func (wb *WorkspaceBox) View() string {
 	}
 
 	stats := theme.Get().WorkspaceView.Details.Render(fmt.Sprintf("CPU:%.1f%% | MEM %.1f%%", wb.CPUUsage, wb.MemUsage))
+	graph := theme.Get().WorkspaceView.Sparkline.Render(sparkline.Render(wb.CPUHistory, graphWidth, 0))
 
-	content := lipgloss.JoinVertical(lipgloss.Center, title, processText, stats)
+	content := lipgloss.JoinVertical(lipgloss.Center, title, processText, stats, graph)
 
 	return boxStyle.Render(content)
 }
@@ -0,0 +1,269 @@
+package keymap
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
+)
+
+// Action identifies what a key does. The values double as the action names
+// in the [keys] table of the config file.
+type Action string
+
+const (
+	ActionNone                  Action = ""
+	ActionQuit                  Action = "quit"
+	ActionNavigateLeft          Action = "navigate_left"
+	ActionNavigateRight         Action = "navigate_right"
+	ActionNavigateUp            Action = "navigate_up"
+	ActionNavigateDown          Action = "navigate_down"
+	ActionScrollUp              Action = "scroll_up"
+	ActionScrollDown            Action = "scroll_down"
+	ActionHalfPageUp            Action = "half_page_up"
+	ActionHalfPageDown          Action = "half_page_down"
+	ActionGoToTop               Action = "go_to_top"
+	ActionGoToBottom            Action = "go_to_bottom"
+	ActionChangeToAllProcsView  Action = "change_to_all_procs_view"
+	ActionChangeToWorkspaceView Action = "change_to_workspace_view"
+	ActionSelectWorkspace       Action = "select_workspace"
+	ActionSortKeyLeft           Action = "sort_key_left"
+	ActionSortKeyRight          Action = "sort_key_right"
+	ActionToggleSortOrder       Action = "toggle_sort_order"
+	ActionCycleGrouping         Action = "cycle_grouping"
+	ActionPushSortKey           Action = "push_sort_key"
+	ActionClearTieBreakers      Action = "clear_tie_breakers"
+	ActionKillProcess           Action = "kill_process"
+	ActionKillProcessForce      Action = "kill_process_force"
+	ActionOpenGraph             Action = "open_graph"
+	ActionOpenSystemGraph       Action = "open_system_graph"
+	ActionGoBack                Action = "go_back"
+	ActionNextTimeWindow        Action = "next_time_window"
+	ActionToggleCPUSeries       Action = "toggle_cpu_series"
+	ActionToggleMEMSeries       Action = "toggle_mem_series"
+	ActionToggleIOSeries        Action = "toggle_io_series"
+	ActionOpenColumnPicker      Action = "open_column_picker"
+	ActionShowDetails           Action = "show_details"
+	ActionToggleMark            Action = "toggle_mark"
+	ActionMarkAll               Action = "mark_all"
+	ActionMarkByFilter          Action = "mark_by_filter"
+	ActionClearMarks            Action = "clear_marks"
+	ActionSendSignal            Action = "send_signal"
+	ActionSuspendProcess        Action = "suspend_process"
+	ActionReniceProcess         Action = "renice_process"
+	ActionCloseWindow           Action = "close_window"
+	ActionSetIOPriority         Action = "set_io_priority"
+	ActionSetAffinity           Action = "set_affinity"
+	ActionToggleHelp            Action = "toggle_help"
+	ActionReplayPause           Action = "replay_pause"
+	ActionReplayStep            Action = "replay_step"
+	ActionReplayStepBack        Action = "replay_step_back"
+	ActionReplaySeekForward     Action = "replay_seek_forward"
+	ActionReplaySeekBack        Action = "replay_seek_back"
+	ActionReplayFaster          Action = "replay_faster"
+	ActionReplaySlower          Action = "replay_slower"
+)
+
+// navigationActions move the selection and are shared by the list screens
+var navigationActions = []Action{
+	ActionNavigateUp, ActionNavigateDown, ActionScrollUp, ActionScrollDown,
+	ActionHalfPageUp, ActionHalfPageDown, ActionGoToTop, ActionGoToBottom,
+}
+
+// replayActions control the playback of a recording and work on every screen.
+// They are disabled unless a recording is replayed.
+var replayActions = []Action{
+	ActionReplayPause, ActionReplayStep, ActionReplayStepBack,
+	ActionReplaySeekForward, ActionReplaySeekBack, ActionReplayFaster, ActionReplaySlower,
+}
+
+// screenActions lists the actions each screen responds to. A key only has to
+// be unique among the actions of one screen.
+var screenActions = map[screens.ScreenType][]Action{
+	screens.WorkspaceSelector: append([]Action{
+		ActionQuit, ActionToggleHelp, ActionNavigateLeft, ActionNavigateRight,
+		ActionChangeToAllProcsView, ActionSelectWorkspace, ActionOpenGraph, ActionOpenSystemGraph,
+	}, append(navigationActions, replayActions...)...),
+	screens.ProcessList: append([]Action{
+		ActionQuit, ActionToggleHelp, ActionChangeToWorkspaceView, ActionSortKeyLeft, ActionSortKeyRight, ActionToggleSortOrder, ActionPushSortKey, ActionClearTieBreakers, ActionCycleGrouping,
+		ActionKillProcess, ActionKillProcessForce, ActionOpenGraph, ActionOpenSystemGraph, ActionOpenColumnPicker, ActionShowDetails,
+		ActionToggleMark, ActionMarkAll, ActionMarkByFilter, ActionClearMarks,
+		ActionSendSignal, ActionSuspendProcess, ActionReniceProcess, ActionSetIOPriority, ActionSetAffinity, ActionCloseWindow,
+	}, append(navigationActions, replayActions...)...),
+	screens.Graph: append([]Action{
+		ActionQuit, ActionToggleHelp, ActionGoBack, ActionNextTimeWindow,
+		ActionToggleCPUSeries, ActionToggleMEMSeries, ActionToggleIOSeries,
+	}, replayActions...),
+}
+
+// bindings maps each action to its binding
+func (km *KeyMap) bindings() map[Action]*key.Binding {
+	return map[Action]*key.Binding{
+		ActionQuit:                  &km.Quit,
+		ActionNavigateLeft:          &km.NavigateLeft,
+		ActionNavigateRight:         &km.NavigateRight,
+		ActionNavigateUp:            &km.NavigateUp,
+		ActionNavigateDown:          &km.NavigateDown,
+		ActionScrollUp:              &km.ScrollUp,
+		ActionScrollDown:            &km.ScrollDown,
+		ActionHalfPageUp:            &km.HalfPageUp,
+		ActionHalfPageDown:          &km.HalfPageDown,
+		ActionGoToTop:               &km.GoToTop,
+		ActionGoToBottom:            &km.GoToBottom,
+		ActionChangeToAllProcsView:  &km.ChangeToAllProcsScreen,
+		ActionChangeToWorkspaceView: &km.ChangeToWorkspaceSelectorScreen,
+		ActionSelectWorkspace:       &km.SelectWorkspace,
+		ActionSortKeyLeft:           &km.SortKeyLeft,
+		ActionSortKeyRight:          &km.SortKeyRight,
+		ActionToggleSortOrder:       &km.ToggleSortOrder,
+		ActionCycleGrouping:         &km.CycleGrouping,
+		ActionPushSortKey:           &km.PushSortKey,
+		ActionClearTieBreakers:      &km.ClearTieBreakers,
+		ActionKillProcess:           &km.KillProcess,
+		ActionKillProcessForce:      &km.KillProcessForce,
+		ActionOpenGraph:             &km.OpenGraph,
+		ActionOpenSystemGraph:       &km.OpenSystemGraph,
+		ActionGoBack:                &km.GoBack,
+		ActionNextTimeWindow:        &km.NextTimeWindow,
+		ActionToggleCPUSeries:       &km.ToggleCPUSeries,
+		ActionToggleMEMSeries:       &km.ToggleMEMSeries,
+		ActionToggleIOSeries:        &km.ToggleIOSeries,
+		ActionOpenColumnPicker:      &km.OpenColumnPicker,
+		ActionShowDetails:           &km.ShowDetails,
+		ActionToggleMark:            &km.ToggleMark,
+		ActionMarkAll:               &km.MarkAll,
+		ActionMarkByFilter:          &km.MarkByFilter,
+		ActionClearMarks:            &km.ClearMarks,
+		ActionSendSignal:            &km.SendSignal,
+		ActionSuspendProcess:        &km.SuspendProcess,
+		ActionReniceProcess:         &km.ReniceProcess,
+		ActionCloseWindow:           &km.CloseWindow,
+		ActionSetIOPriority:         &km.SetIOPriority,
+		ActionSetAffinity:           &km.SetAffinity,
+		ActionToggleHelp:            &km.ToggleHelp,
+		ActionReplayPause:           &km.ReplayPause,
+		ActionReplayStep:            &km.ReplayStep,
+		ActionReplayStepBack:        &km.ReplayStepBack,
+		ActionReplaySeekForward:     &km.ReplaySeekForward,
+		ActionReplaySeekBack:        &km.ReplaySeekBack,
+		ActionReplayFaster:          &km.ReplayFaster,
+		ActionReplaySlower:          &km.ReplaySlower,
+	}
+}
+
+// IsReplayAction reports whether action controls the playback of a recording
+func IsReplayAction(action Action) bool {
+	return slices.Contains(replayActions, action)
+}
+
+// EnableReplay turns on the replay bindings of the installed key map
+func EnableReplay() {
+	bindings := keyMap.bindings()
+	for _, action := range replayActions {
+		bindings[action].SetEnabled(true)
+	}
+}
+
+// Rebind replaces the keys of an action, keeping its help description.
+// Space separated keys form a sequence, e.g. "g g".
+func (km *KeyMap) Rebind(action Action, keys []string) error {
+	binding, ok := km.bindings()[action]
+	if !ok {
+		return fmt.Errorf("unknown action %q", action)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("action %q needs at least one key", action)
+	}
+	binding.SetKeys(keys...)
+	binding.SetHelp(keysText(keys[:1]), binding.Help().Desc)
+	return nil
+}
+
+// Conflict is a key bound to more than one action on the same screen. A key
+// that starts a sequence bound elsewhere conflicts too, as it fires first.
+type Conflict struct {
+	Screen  screens.ScreenType
+	Key     string
+	Actions []Action
+}
+
+func (c Conflict) Error() string {
+	names := make([]string, len(c.Actions))
+	for i, action := range c.Actions {
+		names[i] = string(action)
+	}
+	return fmt.Sprintf("key %q is bound to %s on the %s screen", c.Key, strings.Join(names, " and "), c.Screen)
+}
+
+// Conflicts reports every key that is ambiguous on some screen
+func (km KeyMap) Conflicts() []Conflict {
+	var conflicts []Conflict
+	bindings := km.bindings()
+	for _, screen := range []screens.ScreenType{screens.WorkspaceSelector, screens.ProcessList, screens.Graph} {
+		owners := make(map[string][]Action)
+		var keys []string
+		for _, action := range screenActions[screen] {
+			for _, k := range bindings[action].Keys() {
+				if _, seen := owners[k]; !seen {
+					keys = append(keys, k)
+				}
+				if !slices.Contains(owners[k], action) {
+					owners[k] = append(owners[k], action)
+				}
+			}
+		}
+		for _, k := range keys {
+			actions := append([]Action{}, owners[k]...)
+			for _, other := range keys {
+				if strings.HasPrefix(other, k+" ") {
+					actions = appendMissing(actions, owners[other]...)
+				}
+			}
+			if len(actions) > 1 {
+				conflicts = append(conflicts, Conflict{Screen: screen, Key: k, Actions: actions})
+			}
+		}
+	}
+	return conflicts
+}
+
+func appendMissing(actions []Action, more ...Action) []Action {
+	for _, action := range more {
+		if !slices.Contains(actions, action) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// ActionNames lists every bindable action, sorted
+func ActionNames() []string {
+	km := NewDefaultKeyMap()
+	names := make([]string, 0, len(km.bindings()))
+	for action := range km.bindings() {
+		names = append(names, string(action))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// DefaultBindings returns the keys of every action in the given preset
+func DefaultBindings(preset string) map[string][]string {
+	newKeyMap, ok := presets[preset]
+	if !ok {
+		newKeyMap = NewDefaultKeyMap
+	}
+	km := newKeyMap()
+	defaults := make(map[string][]string)
+	for action, binding := range km.bindings() {
+		defaults[string(action)] = binding.Keys()
+	}
+	return defaults
+}
+
+// helpKey shows a key sequence the way it is typed, "g g" as "gg"
+func helpKey(k string) string {
+	return strings.ReplaceAll(k, " ", "")
+}
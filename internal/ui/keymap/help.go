@@ -0,0 +1,158 @@
+package keymap
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
+)
+
+// Category groups actions in the help overlay
+type Category string
+
+const (
+	CategoryNavigation Category = "Navigation"
+	CategoryViews      Category = "Views"
+	CategorySorting    Category = "Sorting"
+	CategorySelection  Category = "Selection"
+	CategoryProcesses  Category = "Processes"
+	CategoryGraph      Category = "Graph"
+	CategoryReplay     Category = "Replay"
+	CategoryGeneral    Category = "General"
+)
+
+var categoryOrder = []Category{
+	CategoryNavigation, CategoryViews, CategorySorting, CategorySelection, CategoryProcesses, CategoryGraph, CategoryReplay, CategoryGeneral,
+}
+
+var actionCategories = map[Action]Category{
+	ActionNavigateLeft:          CategoryNavigation,
+	ActionNavigateRight:         CategoryNavigation,
+	ActionNavigateUp:            CategoryNavigation,
+	ActionNavigateDown:          CategoryNavigation,
+	ActionScrollUp:              CategoryNavigation,
+	ActionScrollDown:            CategoryNavigation,
+	ActionHalfPageUp:            CategoryNavigation,
+	ActionHalfPageDown:          CategoryNavigation,
+	ActionGoToTop:               CategoryNavigation,
+	ActionGoToBottom:            CategoryNavigation,
+	ActionChangeToAllProcsView:  CategoryViews,
+	ActionChangeToWorkspaceView: CategoryViews,
+	ActionSelectWorkspace:       CategoryViews,
+	ActionOpenGraph:             CategoryViews,
+	ActionOpenSystemGraph:       CategoryViews,
+	ActionOpenColumnPicker:      CategoryViews,
+	ActionSortKeyLeft:           CategorySorting,
+	ActionSortKeyRight:          CategorySorting,
+	ActionToggleSortOrder:       CategorySorting,
+	ActionCycleGrouping:         CategorySorting,
+	ActionPushSortKey:           CategorySorting,
+	ActionClearTieBreakers:      CategorySorting,
+	ActionToggleMark:            CategorySelection,
+	ActionMarkAll:               CategorySelection,
+	ActionMarkByFilter:          CategorySelection,
+	ActionClearMarks:            CategorySelection,
+	ActionShowDetails:           CategoryProcesses,
+	ActionSendSignal:            CategoryProcesses,
+	ActionSuspendProcess:        CategoryProcesses,
+	ActionReniceProcess:         CategoryProcesses,
+	ActionCloseWindow:           CategoryProcesses,
+	ActionSetIOPriority:         CategoryProcesses,
+	ActionSetAffinity:           CategoryProcesses,
+	ActionKillProcess:           CategoryProcesses,
+	ActionKillProcessForce:      CategoryProcesses,
+	ActionNextTimeWindow:        CategoryGraph,
+	ActionToggleCPUSeries:       CategoryGraph,
+	ActionToggleMEMSeries:       CategoryGraph,
+	ActionToggleIOSeries:        CategoryGraph,
+	ActionReplayPause:           CategoryReplay,
+	ActionReplayStep:            CategoryReplay,
+	ActionReplayStepBack:        CategoryReplay,
+	ActionReplaySeekForward:     CategoryReplay,
+	ActionReplaySeekBack:        CategoryReplay,
+	ActionReplayFaster:          CategoryReplay,
+	ActionReplaySlower:          CategoryReplay,
+	ActionQuit:                  CategoryGeneral,
+	ActionToggleHelp:            CategoryGeneral,
+	ActionGoBack:                CategoryGeneral,
+}
+
+// HelpEntry is one line of the help overlay
+type HelpEntry struct {
+	Keys string // every key of the action, e.g. "k/up"
+	Desc string
+}
+
+type HelpSection struct {
+	Category Category
+	Entries  []HelpEntry
+}
+
+// FullHelp lists every binding of a screen, grouped by category
+func (km KeyMap) FullHelp(screen screens.ScreenType) []HelpSection {
+	bindings := km.bindings()
+	byCategory := make(map[Category][]HelpEntry)
+	for _, action := range screenActions[screen] {
+		binding := bindings[action]
+		if !binding.Enabled() {
+			continue
+		}
+		category := actionCategories[action]
+		byCategory[category] = append(byCategory[category], HelpEntry{
+			Keys: keysText(binding.Keys()),
+			Desc: binding.Help().Desc,
+		})
+	}
+
+	var sections []HelpSection
+	for _, category := range categoryOrder {
+		if entries := byCategory[category]; len(entries) > 0 {
+			sections = append(sections, HelpSection{Category: category, Entries: entries})
+		}
+	}
+	return sections
+}
+
+// ShortHelp returns the few bindings shown in a screen's hint bar, most
+// important first since the bar is truncated on narrow terminals
+func (km KeyMap) ShortHelp(screen screens.ScreenType) []key.Binding {
+	bindings := []key.Binding{km.ToggleHelp}
+	switch screen {
+	case screens.WorkspaceSelector:
+		bindings = append(bindings,
+			combined("navigate", km.NavigateLeft, km.NavigateRight, km.NavigateUp, km.NavigateDown),
+			km.SelectWorkspace, km.ChangeToAllProcsScreen, km.OpenGraph)
+	case screens.ProcessList:
+		bindings = append(bindings,
+			combined("move", km.NavigateUp, km.NavigateDown),
+			combined("sort", km.SortKeyLeft, km.SortKeyRight),
+			km.ToggleMark, km.ShowDetails, km.KillProcess, km.SendSignal, km.OpenGraph, km.OpenColumnPicker, km.ChangeToWorkspaceSelectorScreen)
+	case screens.Graph:
+		bindings = append(bindings,
+			km.NextTimeWindow,
+			combined("cpu/mem/io", km.ToggleCPUSeries, km.ToggleMEMSeries, km.ToggleIOSeries),
+			km.GoBack)
+	}
+	return append(bindings, km.Quit)
+}
+
+// combined shows several bindings as one hint, e.g. "[/] sort"
+func combined(desc string, bindings ...key.Binding) key.Binding {
+	var keys, shown []string
+	for _, b := range bindings {
+		keys = append(keys, b.Keys()...)
+		shown = append(shown, b.Help().Key)
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(strings.Join(shown, "/"), desc))
+}
+
+func keysText(keys []string) string {
+	shown := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		shown[i] = helpKey(k)
+	}
+	return strings.Join(shown, "/")
+}
@@ -1,7 +1,10 @@
 package keymap
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,26 +20,111 @@ type KeyMap struct {
 	NavigateDown                    key.Binding
 	ScrollUp                        key.Binding
 	ScrollDown                      key.Binding
+	HalfPageUp                      key.Binding
+	HalfPageDown                    key.Binding
+	GoToTop                         key.Binding
+	GoToBottom                      key.Binding
 	ChangeToAllProcsScreen          key.Binding
 	ChangeToWorkspaceSelectorScreen key.Binding
 	SelectWorkspace                 key.Binding
 	SortKeyLeft                     key.Binding
 	SortKeyRight                    key.Binding
 	ToggleSortOrder                 key.Binding
+	CycleGrouping                   key.Binding
+	PushSortKey                     key.Binding
+	ClearTieBreakers                key.Binding
 	KillProcess                     key.Binding
 	KillProcessForce                key.Binding
+	OpenGraph                       key.Binding
+	OpenSystemGraph                 key.Binding
+	GoBack                          key.Binding
+	NextTimeWindow                  key.Binding
+	ToggleCPUSeries                 key.Binding
+	ToggleMEMSeries                 key.Binding
+	ToggleIOSeries                  key.Binding
+	OpenColumnPicker                key.Binding
+	ShowDetails                     key.Binding
+	ToggleMark                      key.Binding
+	MarkAll                         key.Binding
+	MarkByFilter                    key.Binding
+	ClearMarks                      key.Binding
+	SendSignal                      key.Binding
+	SuspendProcess                  key.Binding
+	ReniceProcess                   key.Binding
+	CloseWindow                     key.Binding
+	SetIOPriority                   key.Binding
+	SetAffinity                     key.Binding
+	ToggleHelp                      key.Binding
+	ReplayPause                     key.Binding
+	ReplayStep                      key.Binding
+	ReplayStepBack                  key.Binding
+	ReplaySeekForward               key.Binding
+	ReplaySeekBack                  key.Binding
+	ReplayFaster                    key.Binding
+	ReplaySlower                    key.Binding
+}
+
+const (
+	PresetDefault = "default"
+	PresetVim     = "vim"
+)
+
+var presets = map[string]func() KeyMap{
+	PresetDefault: NewDefaultKeyMap,
+	PresetVim:     NewVimKeyMap,
 }
 
 var keyMap KeyMap
 
-func Init() {
-	keyMap = NewDefaultKeyMap()
+// pendingKeys holds the start of a key sequence such as "g g" while the rest
+// is typed. Keys are only handled on the UI goroutine.
+var pendingKeys string
+
+// Init builds the key map from a preset and applies overrides, keyed by action name.
+// On error the default key map is installed.
+func Init(preset string, overrides map[string][]string) error {
+	km, err := New(preset, overrides)
+	if err != nil {
+		keyMap = NewDefaultKeyMap()
+		return err
+	}
+	keyMap = km
+	return nil
+}
+
+// New builds a key map from a preset and overrides and checks it for conflicts
+func New(preset string, overrides map[string][]string) (KeyMap, error) {
+	newKeyMap, ok := presets[preset]
+	if !ok {
+		return KeyMap{}, fmt.Errorf("unknown key preset %q (valid: %s)", preset, strings.Join(PresetNames(), ", "))
+	}
+	km := newKeyMap()
+	for action, keys := range overrides {
+		if err := km.Rebind(Action(action), keys); err != nil {
+			return KeyMap{}, err
+		}
+	}
+	var errs []error
+	for _, conflict := range km.Conflicts() {
+		errs = append(errs, conflict)
+	}
+	return km, errors.Join(errs...)
 }
 
 func Get() KeyMap {
 	return keyMap
 }
 
+// PresetNames lists the built-in key presets
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
 func NewDefaultKeyMap() KeyMap {
 	km := KeyMap{}
 	km.setQuitKeys("q", "ctrl+c")
@@ -46,79 +134,111 @@ func NewDefaultKeyMap() KeyMap {
 	km.setNavigateDownKeys("down")
 	km.setScrollUpKeys("pgup")
 	km.setScrollDownKeys("pgdown")
+	km.setHalfPageUpKeys("ctrl+u")
+	km.setHalfPageDownKeys("ctrl+d")
+	km.setGoToTopKeys("home")
+	km.setGoToBottomKeys("end")
 	km.setChangeToAllProcsScreenKeys("p", "ctrl+p")
 	km.setChangeToWorkspaceSelectorScreenKeys("w", "ctrl+w")
 	km.setSelectWorkspaceKeys("enter", "return")
 	km.setSortKeyLeftKeys("[", "<")
 	km.setSortKeyRightKeys("]", ">")
 	km.setToggleSortOrderKeys("ctrl+o")
+	km.setCycleGroupingKeys("v")
+	km.setPushSortKeyKeys("+")
+	km.setClearTieBreakersKeys("-")
 	km.setKillProcessKeys("x")
 	km.setKillProcessForceKeys("X")
+	km.setOpenGraphKeys("t")
+	km.setOpenSystemGraphKeys("T")
+	km.setGoBackKeys("esc", "backspace")
+	km.setNextTimeWindowKeys("tab")
+	km.setToggleCPUSeriesKeys("c")
+	km.setToggleMEMSeriesKeys("m")
+	km.setToggleIOSeriesKeys("i")
+	km.setOpenColumnPickerKeys("e")
+	km.setShowDetailsKeys("enter")
+	km.setToggleMarkKeys(" ")
+	km.setMarkAllKeys("a")
+	km.setMarkByFilterKeys("/")
+	km.setClearMarksKeys("u")
+	km.setSendSignalKeys("s")
+	km.setSuspendProcessKeys("z")
+	km.setReniceProcessKeys("n")
+	km.setCloseWindowKeys("c")
+	km.setSetIOPriorityKeys("i")
+	km.setSetAffinityKeys("P")
+	km.setToggleHelpKeys("?")
+	km.setReplayPauseKeys("|")
+	km.setReplayStepKeys(".")
+	km.setReplayStepBackKeys(",")
+	km.setReplaySeekForwardKeys("shift+right")
+	km.setReplaySeekBackKeys("shift+left")
+	km.setReplayFasterKeys("}")
+	km.setReplaySlowerKeys("{")
+	return km
+}
+
+// NewVimKeyMap adds vim motions on top of the defaults: hjkl, gg/G and ctrl+u/d
+func NewVimKeyMap() KeyMap {
+	km := NewDefaultKeyMap()
+	km.setNavigateLeftKeys("h", "left")
+	km.setNavigateRightKeys("l", "right")
+	km.setNavigateUpKeys("k", "up")
+	km.setNavigateDownKeys("j", "down")
+	km.setScrollUpKeys("ctrl+b", "pgup")
+	km.setScrollDownKeys("ctrl+f", "pgdown")
+	km.setGoToTopKeys("g g", "home")
+	km.setGoToBottomKeys("G", "end")
 	return km
 }
 
-// GetHelpText returns formatted help text for the specified screen type
-func (km KeyMap) GetHelpText(screenType screens.ScreenType) string {
-	switch screenType {
-	case screens.WorkspaceSelector:
-		return km.getWorkspaceSelectorHelpText()
-	case screens.ProcessList:
-		return km.getProcessListHelpText()
-	default:
-		return "unknown screen type"
+// HandleKeyMsg resolves a key press to one of the screen's actions. The first
+// key of a sequence is swallowed (ActionNone, true) until the sequence completes.
+func (km KeyMap) HandleKeyMsg(screen screens.ScreenType, msg tea.KeyMsg) (Action, bool) {
+	typed := msg.String()
+	if pendingKeys != "" {
+		sequence := pendingKeys + " " + typed
+		pendingKeys = ""
+		if action, ok := km.match(screen, sequence); ok {
+			return action, true
+		}
+		if km.startsSequence(screen, sequence) {
+			pendingKeys = sequence
+			return ActionNone, true
+		}
+	}
+	if action, ok := km.match(screen, typed); ok {
+		return action, true
+	}
+	if km.startsSequence(screen, typed) {
+		pendingKeys = typed
+		return ActionNone, true
+	}
+	return ActionNone, false
+}
+
+func (km KeyMap) match(screen screens.ScreenType, typed string) (Action, bool) {
+	bindings := km.bindings()
+	for _, action := range screenActions[screen] {
+		binding := bindings[action]
+		if binding.Enabled() && slices.Contains(binding.Keys(), typed) {
+			return action, true
+		}
 	}
+	return ActionNone, false
 }
 
-func (km KeyMap) getWorkspaceSelectorHelpText() string {
-	navigateKeys := fmt.Sprintf("%s/%s/%s/%s",
-		km.NavigateLeft.Help().Key, km.NavigateRight.Help().Key, km.NavigateUp.Help().Key, km.NavigateDown.Help().Key)
-	scrollKeys := fmt.Sprintf("%s/%s", km.ScrollUp.Help().Key, km.ScrollDown.Help().Key)
-
-	return fmt.Sprintf("%s: navigate, %s: scroll, %s: view all processes, %s: select workspace, %s: quit",
-		navigateKeys, scrollKeys, km.ChangeToAllProcsScreen.Help().Key, km.SelectWorkspace.Help().Key, km.Quit.Help().Key)
-}
-
-func (km KeyMap) getProcessListHelpText() string {
-	return fmt.Sprintf("%s: change to workspace view, %s: sort key left, %s: sort key right, %s: toggle sort order, %s: kill process, %s: kill process force, %s: quit",
-		km.ChangeToWorkspaceSelectorScreen.Help().Key, km.SortKeyLeft.Help().Key, km.SortKeyRight.Help().Key, km.ToggleSortOrder.Help().Key, km.KillProcess.Help().Key, km.KillProcessForce.Help().Key, km.Quit.Help().Key)
-}
-
-// HandleKeyMsg processes key messages for navigation
-func (km KeyMap) HandleKeyMsg(msg tea.KeyMsg) (string, bool) {
-	switch {
-	case key.Matches(msg, km.Quit):
-		return "quit", true
-	case key.Matches(msg, km.NavigateLeft):
-		return "navigate_left", true
-	case key.Matches(msg, km.NavigateRight):
-		return "navigate_right", true
-	case key.Matches(msg, km.NavigateUp):
-		return "navigate_up", true
-	case key.Matches(msg, km.NavigateDown):
-		return "navigate_down", true
-	case key.Matches(msg, km.ScrollUp):
-		return "scroll_up", true
-	case key.Matches(msg, km.ScrollDown):
-		return "scroll_down", true
-	case key.Matches(msg, km.ChangeToAllProcsScreen):
-		return "change_to_all_procs_view", true
-	case key.Matches(msg, km.ChangeToWorkspaceSelectorScreen):
-		return "change_to_workspace_view", true
-	case key.Matches(msg, km.SelectWorkspace):
-		return "select_workspace", true
-	case key.Matches(msg, km.SortKeyLeft):
-		return "sort_key_left", true
-	case key.Matches(msg, km.SortKeyRight):
-		return "sort_key_right", true
-	case key.Matches(msg, km.ToggleSortOrder):
-		return "toggle_sort_order", true
-	case key.Matches(msg, km.KillProcess):
-		return "kill_process", true
-	case key.Matches(msg, km.KillProcessForce):
-		return "kill_process_force", true
-	default:
-		return "", false
+func (km KeyMap) startsSequence(screen screens.ScreenType, typed string) bool {
+	bindings := km.bindings()
+	for _, action := range screenActions[screen] {
+		for _, k := range bindings[action].Keys() {
+			if strings.HasPrefix(k, typed+" ") {
+				return true
+			}
+		}
 	}
+	return false
 }
 
 // Helper methods for initialization
@@ -207,6 +327,24 @@ func (km *KeyMap) setToggleSortOrderKeys(keys ...string) {
 		key.WithHelp(keys[0], "toggle sort order"),
 	)
 }
+func (km *KeyMap) setCycleGroupingKeys(keys ...string) {
+	km.CycleGrouping = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "group by"),
+	)
+}
+func (km *KeyMap) setPushSortKeyKeys(keys ...string) {
+	km.PushSortKey = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "then sort by"),
+	)
+}
+func (km *KeyMap) setClearTieBreakersKeys(keys ...string) {
+	km.ClearTieBreakers = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "single sort key"),
+	)
+}
 func (km *KeyMap) setKillProcessKeys(keys ...string) {
 	km.KillProcess = key.NewBinding(
 		key.WithKeys(keys...),
@@ -218,4 +356,197 @@ func (km *KeyMap) setKillProcessForceKeys(keys ...string) {
 		key.WithKeys(keys...),
 		key.WithHelp(keys[0], "kill process force (SIGKILL)"),
 	)
-}
\ No newline at end of file
+}
+func (km *KeyMap) setOpenGraphKeys(keys ...string) {
+	km.OpenGraph = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "open graph"),
+	)
+}
+func (km *KeyMap) setOpenSystemGraphKeys(keys ...string) {
+	km.OpenSystemGraph = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "open system graph"),
+	)
+}
+func (km *KeyMap) setGoBackKeys(keys ...string) {
+	km.GoBack = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "go back"),
+	)
+}
+func (km *KeyMap) setNextTimeWindowKeys(keys ...string) {
+	km.NextTimeWindow = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "next time window"),
+	)
+}
+func (km *KeyMap) setToggleCPUSeriesKeys(keys ...string) {
+	km.ToggleCPUSeries = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "toggle cpu series"),
+	)
+}
+func (km *KeyMap) setToggleMEMSeriesKeys(keys ...string) {
+	km.ToggleMEMSeries = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "toggle memory series"),
+	)
+}
+func (km *KeyMap) setToggleIOSeriesKeys(keys ...string) {
+	km.ToggleIOSeries = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "toggle io series"),
+	)
+}
+func (km *KeyMap) setHalfPageUpKeys(keys ...string) {
+	km.HalfPageUp = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKey(keys[0]), "half page up"),
+	)
+}
+func (km *KeyMap) setHalfPageDownKeys(keys ...string) {
+	km.HalfPageDown = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKey(keys[0]), "half page down"),
+	)
+}
+func (km *KeyMap) setGoToTopKeys(keys ...string) {
+	km.GoToTop = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKey(keys[0]), "go to top"),
+	)
+}
+func (km *KeyMap) setGoToBottomKeys(keys ...string) {
+	km.GoToBottom = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKey(keys[0]), "go to bottom"),
+	)
+}
+func (km *KeyMap) setOpenColumnPickerKeys(keys ...string) {
+	km.OpenColumnPicker = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "choose columns"),
+	)
+}
+func (km *KeyMap) setShowDetailsKeys(keys ...string) {
+	km.ShowDetails = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], "details/expand"),
+	)
+}
+func (km *KeyMap) setToggleMarkKeys(keys ...string) {
+	km.ToggleMark = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "mark"),
+	)
+}
+func (km *KeyMap) setMarkAllKeys(keys ...string) {
+	km.MarkAll = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "mark all"),
+	)
+}
+func (km *KeyMap) setMarkByFilterKeys(keys ...string) {
+	km.MarkByFilter = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "mark matching"),
+	)
+}
+func (km *KeyMap) setClearMarksKeys(keys ...string) {
+	km.ClearMarks = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "clear marks"),
+	)
+}
+func (km *KeyMap) setSendSignalKeys(keys ...string) {
+	km.SendSignal = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "send signal"),
+	)
+}
+func (km *KeyMap) setSuspendProcessKeys(keys ...string) {
+	km.SuspendProcess = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "suspend/resume"),
+	)
+}
+func (km *KeyMap) setReniceProcessKeys(keys ...string) {
+	km.ReniceProcess = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "renice"),
+	)
+}
+func (km *KeyMap) setCloseWindowKeys(keys ...string) {
+	km.CloseWindow = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "close window"),
+	)
+}
+func (km *KeyMap) setSetIOPriorityKeys(keys ...string) {
+	km.SetIOPriority = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "io priority"),
+	)
+}
+func (km *KeyMap) setSetAffinityKeys(keys ...string) {
+	km.SetAffinity = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "cpu affinity"),
+	)
+}
+func (km *KeyMap) setToggleHelpKeys(keys ...string) {
+	km.ToggleHelp = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKey(keys[0]), "help"),
+	)
+}
+func (km *KeyMap) setReplayPauseKeys(keys ...string) {
+	km.ReplayPause = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "pause/resume"),
+		key.WithDisabled(),
+	)
+}
+func (km *KeyMap) setReplayStepKeys(keys ...string) {
+	km.ReplayStep = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "next snapshot"),
+		key.WithDisabled(),
+	)
+}
+func (km *KeyMap) setReplayStepBackKeys(keys ...string) {
+	km.ReplayStepBack = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "previous snapshot"),
+		key.WithDisabled(),
+	)
+}
+func (km *KeyMap) setReplaySeekForwardKeys(keys ...string) {
+	km.ReplaySeekForward = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "forward 1m"),
+		key.WithDisabled(),
+	)
+}
+func (km *KeyMap) setReplaySeekBackKeys(keys ...string) {
+	km.ReplaySeekBack = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "back 1m"),
+		key.WithDisabled(),
+	)
+}
+func (km *KeyMap) setReplayFasterKeys(keys ...string) {
+	km.ReplayFaster = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "faster"),
+		key.WithDisabled(),
+	)
+}
+func (km *KeyMap) setReplaySlowerKeys(keys ...string) {
+	km.ReplaySlower = key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keysText(keys[:1]), "slower"),
+		key.WithDisabled(),
+	)
+}
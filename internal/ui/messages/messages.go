@@ -1,7 +1,11 @@
 package messages
 
 import (
+	"syscall"
+
+	"github.com/paulvinueza30/hyprtask/internal/history"
 	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
 	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
 	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
 )
@@ -9,12 +13,14 @@ import (
 type WorkspaceDataMsg struct {
 	Workspaces []*viewmodel.WorkspaceData
 	Count      int
+	History    *history.Store
 }
 
-func NewWorkspaceDataMsg(hyprData viewmodel.WorkspaceDisplayData) WorkspaceDataMsg {
+func NewWorkspaceDataMsg(hyprData viewmodel.WorkspaceDisplayData, hist *history.Store) WorkspaceDataMsg {
 	return WorkspaceDataMsg{
 		Workspaces: hyprData.Workspaces,
 		Count:      hyprData.WorkspaceCount,
+		History:    hist,
 	}
 }
 
@@ -24,7 +30,7 @@ type ChangeScreenMsg[T ScreenMsg] struct {
 }
 
 type ScreenMsg interface {
-	ProcessListMsg | WorkspaceListMsg
+	ProcessListMsg | WorkspaceListMsg | GraphMsg
 }
 
 // Screen-specific message types
@@ -33,12 +39,43 @@ type ProcessListMsg struct {
 	WorkspaceID   *int                      // nil = all processes, &workspaceID = specific workspace (for logic)
 	WorkspaceName *string                   // nil = all processes, &workspaceName = specific workspace (for display)
 	Processes     []taskmanager.TaskProcess // actual process data
+	Groups        []viewmodel.ProcessGroup  // Processes grouped, nil unless grouping
+	GroupBy       viewmodel.GroupBy
+	History       *history.Store            // per-process samples for trend columns
+	CPUs          int                       // number of CPUs, 0 until the first sample
 }
 
 type WorkspaceListMsg struct {
 	// Future workspace-specific data
 }
 
+type GraphTarget int
+
+const (
+	GraphSystem GraphTarget = iota
+	GraphProcess
+	GraphWorkspace
+)
+
+type GraphMsg struct {
+	Target  GraphTarget
+	ID      int    // PID or workspace ID, unused for GraphSystem
+	Label   string // for display
+	History *history.Store
+}
+
+// GoBackMsg returns to the screen that was active before the current one
+type GoBackMsg struct{}
+
+// ShowHelpMsg opens the key binding overlay for the active screen
+type ShowHelpMsg struct{}
+
+// ReplayControlMsg is a replay key pressed on any screen, for the model to
+// pass on to the player
+type ReplayControlMsg struct {
+	Action keymap.Action
+}
+
 func NewChangeScreenMsg[T ScreenMsg](screenType screens.ScreenType, screenMsg T) ChangeScreenMsg[T] {
 	return ChangeScreenMsg[T]{
 		ScreenType: screenType,
@@ -54,6 +91,14 @@ func NewProcessListMsg(workspaceID *int, workspaceName *string) ProcessListMsg {
 	}
 }
 
+func NewGraphMsg(target GraphTarget, id int, label string) GraphMsg {
+	return GraphMsg{
+		Target: target,
+		ID:     id,
+		Label:  label,
+	}
+}
+
 func NewAllProcessesMsg() ProcessListMsg {
 	return NewProcessListMsg(nil, nil)
 }
@@ -65,23 +110,46 @@ func NewWorkspaceProcessesMsg(workspaceID int, workspaceName string) ProcessList
 type ChangeSortOptionMsg struct{
 	Key viewmodel.SortKey
 	Order viewmodel.SortOrder
+	ThenBy []viewmodel.SortOption // tie-breakers, in priority order
 }
 
-func NewChangeSortOptionMsg(key viewmodel.SortKey, order viewmodel.SortOrder) ChangeSortOptionMsg {
+func NewChangeSortOptionMsg(key viewmodel.SortKey, order viewmodel.SortOrder, thenBy []viewmodel.SortOption) ChangeSortOptionMsg {
 	return ChangeSortOptionMsg{
 		Key: key,
 		Order: order,
+		ThenBy: thenBy,
 	}
 }
 
-type KillProcessMsg struct {
-	PID   int
-	Force bool // true for SIGKILL, false for SIGTERM
+// ChangeGroupByMsg asks the view model to group the process list differently
+type ChangeGroupByMsg struct {
+	GroupBy viewmodel.GroupBy
 }
 
-func NewKillProcessMsg(pid int, force bool) KillProcessMsg {
-	return KillProcessMsg{
-		PID:   pid,
-		Force: force,
-	}
-}
\ No newline at end of file
+// ProcessActionMsg asks the task manager to act on several processes at once
+type ProcessActionMsg struct {
+	Type   taskmanager.TaskActionType
+	PIDs   []int
+	Signal  syscall.Signal      // for TaskActionSignal
+	Nice    int                 // for TaskActionRenice
+	Scope   taskmanager.Scope   // for TaskActionRenice and TaskActionIOPriority
+	IOClass taskmanager.IOClass // for TaskActionIOPriority
+	IOLevel int                 // for TaskActionIOPriority
+	CPUs    []int               // for TaskActionAffinity
+}
+
+// PinnedCoresMsg tells the usage bars which cores the selected process is
+// pinned to, nil when it is not pinned
+type PinnedCoresMsg struct {
+	Cores []int
+}
+
+// ActionResultMsg carries the per process outcome of a ProcessActionMsg
+type ActionResultMsg struct {
+	Result taskmanager.ActionResult
+}
+
+// SaveColumnsMsg asks for the process list column choice to be written to the config file
+type SaveColumnsMsg struct {
+	Columns []string
+}
@@ -1,13 +1,22 @@
 package ui
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/config"
 	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/recording"
 	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/helpoverlay"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/usagebars"
 	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
 	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
 	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
+	"github.com/paulvinueza30/hyprtask/internal/ui/screens/graphview"
 	"github.com/paulvinueza30/hyprtask/internal/ui/screens/processlist"
 	"github.com/paulvinueza30/hyprtask/internal/ui/screens/workspaceselector"
 	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
@@ -18,35 +27,100 @@ type Model struct {
 	displayDataChan <-chan viewmodel.DisplayData
 	viewActionChan  chan<- viewmodel.ViewAction
 	taskActionChan  chan<- taskmanager.TaskAction
+	resultChan      <-chan taskmanager.ActionResult
 
 	displayData  viewmodel.DisplayData
 	windowWidth  int
 	windowHeight int
+	headerHeight int
+
+	usageBars   *usagebars.UsageBars
+	helpOverlay *helpoverlay.HelpOverlay
+	pinnedCores []int // cores the selected process is pinned to
 
-	screens      map[screens.ScreenType]tea.Model
-	activeScreen screens.ScreenType
+	screens        map[screens.ScreenType]tea.Model
+	activeScreen   screens.ScreenType
+	previousScreen screens.ScreenType
 	
 	processListWorkspaceID *int // nil = all processes, &workspaceID = specific workspace
+
+	configPath string
+
+	replay *recording.Player // nil unless showing a recording
 }
 
-func NewModel(ddChan chan viewmodel.DisplayData, viewActChan chan viewmodel.ViewAction, taskActChan chan taskmanager.TaskAction) *Model {
-	theme.Init()
-	keymap.Init()
+// replaySeek is how far the seek keys jump in a recording
+const replaySeek = time.Minute
+
+func NewModel(cfg config.Config, ddChan chan viewmodel.DisplayData, viewActChan chan viewmodel.ViewAction, taskActChan chan taskmanager.TaskAction, resultChan chan taskmanager.ActionResult) *Model {
+	theme.Init(palette(cfg))
+	if err := keymap.Init(cfg.KeyPreset, cfg.Keys); err != nil {
+		logger.Log.Error("invalid key bindings, using defaults", "error", err)
+	}
+	sortOptions, err := viewmodel.ParseViewOptions(cfg.Sort.Key, cfg.Sort.Order)
+	if err != nil {
+		logger.Log.Error("invalid sort options, using defaults", "error", err)
+	}
+	activeScreen := screens.WorkspaceSelector
+	if cfg.DefaultScreen == config.ScreenProcesses {
+		activeScreen = screens.ProcessList
+	}
 
 	model := &Model{
 		displayDataChan: ddChan,
 		viewActionChan:  viewActChan,
 		taskActionChan:  taskActChan,
-		// TODO: Make this dynamic based on if theres workspaces or not
-		activeScreen: screens.WorkspaceSelector,
+		resultChan:      resultChan,
+		usageBars:       usagebars.NewUsageBars(),
+		helpOverlay:     helpoverlay.NewHelpOverlay(),
+		activeScreen:    activeScreen,
+		previousScreen:  activeScreen,
+		configPath:      cfg.Path,
 		screens: map[screens.ScreenType]tea.Model{
 			screens.WorkspaceSelector: workspaceselector.NewWorkspaceSelectorView(),
-			screens.ProcessList:       processlist.NewProcessList([]taskmanager.TaskProcess{}),
+			screens.ProcessList:       processlist.NewProcessList([]taskmanager.TaskProcess{}, cfg.Columns, sortOptions),
+			screens.Graph:             graphview.NewGraphView(),
 		},
 	}
 	return model
 }
 
+// UseReplay shows that the display data comes from p and enables the replay keys
+func (m *Model) UseReplay(p *recording.Player) {
+	m.replay = p
+	keymap.EnableReplay()
+}
+
+// palette resolves the configured theme and applies the color overrides on top
+func palette(cfg config.Config) theme.Palette {
+	p, err := theme.Resolve(cfg.Theme.Name, cfg.ThemesDir())
+	if err != nil {
+		logger.Log.Error("could not load theme, using the default", "theme", cfg.Theme.Name, "error", err)
+		p = theme.DefaultPalette()
+	}
+	colors := cfg.Theme.Colors
+	overrides := []struct {
+		value  string
+		target *string
+	}{
+		{colors.Background, &p.Background},
+		{colors.Foreground, &p.Foreground},
+		{colors.Muted, &p.Muted},
+		{colors.Border, &p.Border},
+		{colors.Accent, &p.Accent},
+		{colors.Warning, &p.Warning},
+		{colors.Success, &p.Success},
+		{colors.Error, &p.Error},
+		{colors.Highlight, &p.Highlight},
+	}
+	for _, o := range overrides {
+		if o.value != "" {
+			*o.target = o.value
+		}
+	}
+	return p
+}
+
 func (m *Model) Init() tea.Cmd {
 	listenCmd := m.listenToDisplayDataChan()
 	var screenCmds []tea.Cmd
@@ -55,7 +129,7 @@ func (m *Model) Init() tea.Cmd {
 		screenCmds = append(screenCmds, screen.Init())
 	}
 
-	return tea.Batch(listenCmd, tea.Batch(screenCmds...))
+	return tea.Batch(listenCmd, m.listenToResultChan(), tea.Batch(screenCmds...))
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -66,12 +140,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case viewmodel.DisplayData:
 		m.displayData = msg
 		cmds = append(cmds, m.listenToDisplayDataChan())
+		m.usageBars.Update(usagebars.UpdateUsageMsg{Usage: msg.System})
+		cmds = append(cmds, m.resizeIfHeaderChanged()...)
 		cmds = append(cmds, m.updateWorkspaceSelectorWithDisplayData()...)
 		cmds = append(cmds, m.updateProcessListWithDisplayData()...)
 
 	case messages.ChangeScreenMsg[messages.ProcessListMsg]:
-		processes := m.getProcsForWorkspace(msg.ScreenMsg.WorkspaceID)
-		msg.ScreenMsg.Processes = processes
+		m.fillProcessListMsg(&msg.ScreenMsg)
 		m.SetActiveScreen(msg.ScreenType)
 		
 		// Store the workspace context
@@ -81,16 +156,47 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.ChangeScreenMsg[messages.WorkspaceListMsg]:
 		m.SetActiveScreen(msg.ScreenType)
 		broadcastMsg = msg.ScreenMsg
+	case messages.ChangeScreenMsg[messages.GraphMsg]:
+		msg.ScreenMsg.History = m.displayData.History
+		m.SetActiveScreen(msg.ScreenType)
+		broadcastMsg = msg.ScreenMsg
+	case messages.GoBackMsg:
+		m.SetActiveScreen(m.previousScreen)
+	case messages.ShowHelpMsg:
+		m.helpOverlay.Show(m.activeScreen)
+	case messages.ReplayControlMsg:
+		m.controlReplay(msg.Action)
 
 	case tea.WindowSizeMsg:
 		m.windowWidth = msg.Width
 		m.windowHeight = msg.Height
+		m.usageBars.Update(msg)
+		m.headerHeight = lipgloss.Height(m.renderHeader())
 		// Create a copy for broadcasting (space for the header)
-		broadcastMsg = tea.WindowSizeMsg{
-			Width:  msg.Width,
-			Height: msg.Height - 3,
-		}
+		broadcastMsg = m.contentSizeMsg()
+		m.helpOverlay.Update(broadcastMsg)
 	case tea.KeyMsg:
+		if m.helpOverlay.Visible() {
+			m.helpOverlay.Update(msg)
+			break
+		}
+		if activeScreen, exists := m.screens[m.activeScreen]; exists {
+			updatedScreen, cmd := activeScreen.Update(msg)
+			m.screens[m.activeScreen] = updatedScreen
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+	case tea.MouseMsg:
+		if m.helpOverlay.Visible() {
+			m.helpOverlay.Update(msg)
+			break
+		}
+		// Screens lay out their content from the top of the area below the header
+		msg.Y -= m.headerHeight
+		if msg.Y < 0 {
+			break
+		}
 		if activeScreen, exists := m.screens[m.activeScreen]; exists {
 			updatedScreen, cmd := activeScreen.Update(msg)
 			m.screens[m.activeScreen] = updatedScreen
@@ -100,8 +206,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case messages.ChangeSortOptionMsg:
 		m.sendSortActionToViewModel(msg)
-	case messages.KillProcessMsg:
-		m.sendKillActionToTaskManager(msg)
+	case messages.ChangeGroupByMsg:
+		m.viewActionChan <- viewmodel.ViewAction{Type: viewmodel.ViewActionGroup, NewGroupBy: msg.GroupBy}
+		logger.Log.Info("Sending group action to viewmodel", "action", msg)
+	case messages.ProcessActionMsg:
+		m.sendProcessActionToTaskManager(msg)
+	case taskmanager.ActionResult:
+		cmds = append(cmds, m.listenToResultChan())
+		if screen, exists := m.screens[screens.ProcessList]; exists {
+			updatedScreen, cmd := screen.Update(messages.ActionResultMsg{Result: msg})
+			m.screens[screens.ProcessList] = updatedScreen
+			cmds = append(cmds, cmd)
+		}
+	case messages.PinnedCoresMsg:
+		m.pinnedCores = msg.Cores
+		m.syncPinnedCores()
+	case messages.SaveColumnsMsg:
+		cmds = append(cmds, saveColumns(m.configPath, msg.Columns))
 	default:
 	if activeScreen, exists := m.screens[m.activeScreen]; exists {
 			updatedScreen, cmd := activeScreen.Update(msg)
@@ -120,21 +241,100 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) View() string {
-	header := theme.Get().Header.
-		Width(m.windowWidth).Height(m.windowHeight / 10).
-		Align(lipgloss.Center).Render("HyprTask")
+	header := m.renderHeader()
 
 	content := m.screens[m.activeScreen].View()
+	if m.helpOverlay.Visible() {
+		content = m.helpOverlay.View()
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Center, header, content)
 }
 
+func (m *Model) renderHeader() string {
+	text := "HyprTask"
+	if m.replay != nil {
+		text += " · " + replayStatus(m.replay.Status())
+	}
+	title := theme.Get().Header.
+		Width(m.windowWidth).Padding(0, theme.HeaderPadding).
+		Align(lipgloss.Center).Render(text)
+
+	return lipgloss.JoinVertical(lipgloss.Center, title, m.usageBars.View())
+}
+
+// replayStatus shows where a replay is, e.g. "replay 14:03:05 (12/340, 2x)"
+func replayStatus(status recording.Status) string {
+	state := strconv.FormatFloat(status.Speed, 'g', -1, 64) + "x"
+	if status.Paused {
+		state = "paused"
+	}
+	return fmt.Sprintf("replay %s (%d/%d, %s)", status.Time.Local().Format(time.TimeOnly), status.Index+1, status.Frames, state)
+}
+
+func (m *Model) controlReplay(action keymap.Action) {
+	if m.replay == nil {
+		return
+	}
+	switch action {
+	case keymap.ActionReplayPause:
+		m.replay.TogglePause()
+	case keymap.ActionReplayStep:
+		m.replay.Step(1)
+	case keymap.ActionReplayStepBack:
+		m.replay.Step(-1)
+	case keymap.ActionReplaySeekForward:
+		m.replay.Seek(replaySeek)
+	case keymap.ActionReplaySeekBack:
+		m.replay.Seek(-replaySeek)
+	case keymap.ActionReplayFaster:
+		m.replay.Faster()
+	case keymap.ActionReplaySlower:
+		m.replay.Slower()
+	}
+}
+
+// contentSizeMsg returns the window size left for screens below the header
+func (m *Model) contentSizeMsg() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{
+		Width:  m.windowWidth,
+		Height: m.windowHeight - m.headerHeight,
+	}
+}
+
+// resizeIfHeaderChanged re-sizes the screens when the header grows or shrinks,
+// e.g. once the first system usage sample reveals the core count
+func (m *Model) resizeIfHeaderChanged() []tea.Cmd {
+	if m.windowWidth == 0 {
+		return nil
+	}
+	height := lipgloss.Height(m.renderHeader())
+	if height == m.headerHeight {
+		return nil
+	}
+	m.headerHeight = height
+	m.helpOverlay.Update(m.contentSizeMsg())
+	return m.broadcastToScreens(m.contentSizeMsg())
+}
+
 func (m *Model) SetActiveScreen(st screens.ScreenType) {
-	if _, exists := m.screens[st]; exists {
+	if _, exists := m.screens[st]; exists && st != m.activeScreen {
+		m.previousScreen = m.activeScreen
 		m.activeScreen = st
+		m.syncPinnedCores()
 	}
 }
 
+// syncPinnedCores highlights the cores of the selected process while the
+// process list is shown
+func (m *Model) syncPinnedCores() {
+	var cores []int
+	if m.activeScreen == screens.ProcessList {
+		cores = m.pinnedCores
+	}
+	m.usageBars.Update(usagebars.HighlightCoresMsg{Cores: cores})
+}
+
 func (m *Model) listenToDisplayDataChan() tea.Cmd {
 	return func() tea.Msg {
 		displayData := <-m.displayDataChan
@@ -142,6 +342,12 @@ func (m *Model) listenToDisplayDataChan() tea.Cmd {
 	}
 }
 
+func (m *Model) listenToResultChan() tea.Cmd {
+	return func() tea.Msg {
+		return <-m.resultChan
+	}
+}
+
 func (m *Model) getProcsForWorkspace(workspaceID *int) []taskmanager.TaskProcess {
 	if workspaceID == nil {
 		return m.displayData.All
@@ -153,6 +359,26 @@ func (m *Model) getProcsForWorkspace(workspaceID *int) []taskmanager.TaskProcess
 	}
 }
 
+// getGroupsForWorkspace is getProcsForWorkspace for the grouped rows
+func (m *Model) getGroupsForWorkspace(workspaceID *int) []viewmodel.ProcessGroup {
+	if workspaceID == nil {
+		return m.displayData.Groups
+	}
+	if workspaceData, exists := m.displayData.Hypr.WorkspaceToProcs[*workspaceID]; exists {
+		return workspaceData.Groups
+	}
+	return nil
+}
+
+// fillProcessListMsg adds the current display data for the workspace of msg
+func (m *Model) fillProcessListMsg(msg *messages.ProcessListMsg) {
+	msg.Processes = m.getProcsForWorkspace(msg.WorkspaceID)
+	msg.Groups = m.getGroupsForWorkspace(msg.WorkspaceID)
+	msg.GroupBy = m.displayData.GroupBy
+	msg.History = m.displayData.History
+	msg.CPUs = len(m.displayData.System.Cores)
+}
+
 func (m *Model) broadcastToScreens(msg tea.Msg) []tea.Cmd {
 	var cmds []tea.Cmd
 	for screenType, screen := range m.screens {
@@ -166,7 +392,7 @@ func (m *Model) broadcastToScreens(msg tea.Msg) []tea.Cmd {
 func (m *Model) updateWorkspaceSelectorWithDisplayData() []tea.Cmd {
 	var cmds []tea.Cmd
 
-	workspaceMsg := messages.NewWorkspaceDataMsg(m.displayData.Hypr)
+	workspaceMsg := messages.NewWorkspaceDataMsg(m.displayData.Hypr, m.displayData.History)
 	if screen, exists := m.screens[screens.WorkspaceSelector]; exists {
 		updatedScreen, cmd := screen.Update(workspaceMsg)
 		m.screens[screens.WorkspaceSelector] = updatedScreen
@@ -187,16 +413,15 @@ func (m *Model) updateProcessListWithDisplayData() []tea.Cmd {
 	if m.processListWorkspaceID == nil {
 		// Currently viewing all processes
 		processMsg = messages.NewAllProcessesMsg()
-		processMsg.Processes = m.displayData.All
 	} else {
 		// Currently viewing a specific workspace
 		workspaceID := *m.processListWorkspaceID
 		processMsg = messages.ProcessListMsg{
 			WorkspaceID:   m.processListWorkspaceID,
 			WorkspaceName: m.getWorkspaceNameByID(workspaceID),
-			Processes:     m.getProcsForWorkspace(m.processListWorkspaceID),
 		}
 	}
+	m.fillProcessListMsg(&processMsg)
 	
 	if screen, exists := m.screens[screens.ProcessList]; exists {
 		updatedScreen, cmd := screen.Update(processMsg)
@@ -212,18 +437,35 @@ func (m *Model) sendSortActionToViewModel(msg messages.ChangeSortOptionMsg){
 	m.viewActionChan <- viewmodel.ViewAction{
 		NewSortKey: msg.Key,
 		NewSortOrder: msg.Order,
+		NewThenBy: msg.ThenBy,
 	}
 	logger.Log.Info("Sending sort action to viewmodel", "action", msg)
 }
-func (m *Model) sendKillActionToTaskManager(msg messages.KillProcessMsg){
-	m.taskActionChan <- taskmanager.TaskAction{
-		Type:    taskmanager.TaskActionKill,
-		Payload: taskmanager.KillProcessPayload{
-			PID: msg.PID,
-			Force: msg.Force,
-		},
+func (m *Model) sendProcessActionToTaskManager(msg messages.ProcessActionMsg) {
+	action := taskmanager.TaskAction{Type: msg.Type}
+	switch msg.Type {
+	case taskmanager.TaskActionSignal:
+		action.Payload = taskmanager.SignalPayload{PIDs: msg.PIDs, Signal: msg.Signal}
+	case taskmanager.TaskActionRenice:
+		action.Payload = taskmanager.RenicePayload{PIDs: msg.PIDs, Scope: msg.Scope, Nice: msg.Nice}
+	case taskmanager.TaskActionIOPriority:
+		action.Payload = taskmanager.IOPriorityPayload{PIDs: msg.PIDs, Scope: msg.Scope, Class: msg.IOClass, Level: msg.IOLevel}
+	case taskmanager.TaskActionAffinity:
+		action.Payload = taskmanager.AffinityPayload{PIDs: msg.PIDs, Scope: msg.Scope, CPUs: msg.CPUs}
+	case taskmanager.TaskActionCloseWindow:
+		action.Payload = taskmanager.CloseWindowPayload{PIDs: msg.PIDs}
+	}
+	m.taskActionChan <- action
+	logger.Log.Info("Sending process action to taskmanager", "action", msg)
+}
+
+func saveColumns(path string, columns []string) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveColumns(path, columns); err != nil {
+			logger.Log.Error("could not save column choice", "path", path, "error", err)
+		}
+		return nil
 	}
-	logger.Log.Info("Sending kill action to taskmanager", "action", msg)
 }
 
 func (m *Model) getWorkspaceNameByID(workspaceID int) *string {
@@ -0,0 +1,51 @@
+// Package mouse holds the small helpers the screens share for mouse input
+package mouse
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DoubleClickInterval is the longest gap between two clicks of a double click
+const DoubleClickInterval = 400 * time.Millisecond
+
+// WheelStep is how many rows one notch of the scroll wheel moves
+const WheelStep = 3
+
+// IsLeftClick reports whether msg is a press of the left button
+func IsLeftClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// WheelDelta returns -1 for wheel up, 1 for wheel down and 0 for anything else
+func WheelDelta(msg tea.MouseMsg) int {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return -1
+	case tea.MouseButtonWheelDown:
+		return 1
+	}
+	return 0
+}
+
+// ClickTracker detects two clicks on the same target in quick succession.
+// Targets are whatever the screen hit-tests to, e.g. a row or tile index.
+type ClickTracker struct {
+	target int
+	at     time.Time
+}
+
+// Click records a click on target and reports whether it completes a double click
+func (c *ClickTracker) Click(target int) bool {
+	now := time.Now()
+	double := !c.at.IsZero() && c.target == target && now.Sub(c.at) <= DoubleClickInterval
+	if double {
+		// A third click starts a new pair instead of counting as another double click
+		c.at = time.Time{}
+	} else {
+		c.at = now
+	}
+	c.target = target
+	return double
+}
@@ -0,0 +1,155 @@
+package graphview
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/graph"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/hintbar"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/viewtitle"
+	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
+	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+type GraphView struct {
+	stateManager *stateManager
+	usageGraph   *graph.Graph
+	ioGraph      *graph.Graph
+
+	Title  *viewtitle.ViewTitle
+	width  int
+	height int
+}
+
+func NewGraphView() *GraphView {
+	return &GraphView{
+		stateManager: newStateManager(),
+		usageGraph:   graph.NewGraph("CPU / Memory", formatPercent),
+		ioGraph:      graph.NewGraph("Disk I/O", formatRate),
+		Title:        viewtitle.NewViewTitle("System"),
+	}
+}
+
+func (g *GraphView) Init() tea.Cmd {
+	return nil
+}
+
+func (g *GraphView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case messages.GraphMsg:
+		g.stateManager.setState(msg)
+	case tea.KeyMsg:
+		return g, g.stateManager.handleKeyMsg(msg)
+	case tea.WindowSizeMsg:
+		g.width = msg.Width
+		g.height = msg.Height
+	}
+	return g, nil
+}
+
+func (g *GraphView) View() string {
+	state := g.stateManager.state
+	window := g.stateManager.getWindow()
+	samples := g.stateManager.getSamples()
+	// the graph ends at the latest sample, which is in the past when replaying a recording
+	now := time.Now()
+	if len(samples) > 0 {
+		now = samples[len(samples)-1].Time
+	}
+
+	g.Title.Text = fmt.Sprintf("%s — last %s", g.targetLabel(), formatWindow(window))
+	title := lipgloss.PlaceHorizontal(g.width, lipgloss.Center, g.Title.View())
+	instructions := lipgloss.PlaceHorizontal(g.width, lipgloss.Center,
+		hintbar.Render(keymap.Get().ShortHelp(screens.Graph), g.width))
+
+	// title and instructions plus a blank line between panels
+	available := g.height - lipgloss.Height(title) - lipgloss.Height(instructions) - 1
+	usageHeight := available
+	if state.series.io {
+		usageHeight = available / 2
+	}
+
+	var series []graph.Series
+	if state.series.cpu {
+		series = append(series, g.newSeries("CPU", samples, history.CPU, len(series)))
+	}
+	if state.series.mem {
+		series = append(series, g.newSeries("MEM", samples, history.MEM, len(series)))
+	}
+	g.usageGraph.Series = series
+	g.usageGraph.Window = window
+	g.usageGraph.Now = now
+	g.usageGraph.SetSize(g.width, usageHeight)
+
+	panels := []string{title}
+	if len(samples) < 2 {
+		panels = append(panels, lipgloss.Place(g.width, available, lipgloss.Center, lipgloss.Center,
+			"Collecting samples…"))
+	} else {
+		panels = append(panels, g.usageGraph.View())
+		if state.series.io {
+			g.ioGraph.Series = []graph.Series{
+				g.newSeries("Read", samples, func(s history.Sample) float64 { return s.IORead }, 2),
+				g.newSeries("Write", samples, func(s history.Sample) float64 { return s.IOWrite }, 3),
+			}
+			g.ioGraph.Window = window
+			g.ioGraph.Now = now
+			g.ioGraph.SetSize(g.width, available-usageHeight)
+			panels = append(panels, "", g.ioGraph.View())
+		}
+	}
+	panels = append(panels, instructions)
+
+	return lipgloss.JoinVertical(lipgloss.Left, panels...)
+}
+
+func (g *GraphView) targetLabel() string {
+	state := g.stateManager.state
+	switch state.target {
+	case messages.GraphProcess:
+		return fmt.Sprintf("Process %s (%d)", state.label, state.id)
+	case messages.GraphWorkspace:
+		return "Workspace " + state.label
+	default:
+		return "System"
+	}
+}
+
+func (g *GraphView) newSeries(name string, samples []history.Sample, metric func(history.Sample) float64, colorIndex int) graph.Series {
+	points := make([]graph.Point, len(samples))
+	for i, s := range samples {
+		points[i] = graph.Point{Time: s.Time, Value: metric(s)}
+	}
+	colors := theme.Get().Graph.Series
+	style := lipgloss.NewStyle()
+	if len(colors) > 0 {
+		style = colors[colorIndex%len(colors)]
+	}
+	return graph.Series{Name: name, Points: points, Style: style}
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v)
+}
+
+func formatRate(v float64) string {
+	const unit = 1024
+	if v < unit {
+		return fmt.Sprintf("%.0fB/s", v)
+	}
+	div, exp := float64(unit), 0
+	for n := v / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB/s", v/div, "KMGTPE"[exp])
+}
+
+func formatWindow(d time.Duration) string {
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
@@ -0,0 +1,107 @@
+package graphview
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
+	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
+)
+
+var timeWindows = []time.Duration{
+	1 * time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+}
+
+type seriesToggles struct {
+	cpu bool
+	mem bool
+	io  bool
+}
+
+type graphState struct {
+	target      messages.GraphTarget
+	id          int
+	label       string
+	history     *history.Store
+	windowIndex int
+	series      seriesToggles
+}
+
+type stateManager struct {
+	state *graphState
+}
+
+func newStateManager() *stateManager {
+	return &stateManager{
+		state: &graphState{
+			target:      messages.GraphSystem,
+			windowIndex: 1,
+			series:      seriesToggles{cpu: true, mem: true},
+		},
+	}
+}
+
+func (sm *stateManager) setState(msg messages.GraphMsg) {
+	sm.state.target = msg.Target
+	sm.state.id = msg.ID
+	sm.state.label = msg.Label
+	sm.state.history = msg.History
+}
+
+func (sm *stateManager) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	action, handled := keymap.Get().HandleKeyMsg(screens.Graph, msg)
+	if !handled {
+		return nil
+	}
+	if keymap.IsReplayAction(action) {
+		return func() tea.Msg {
+			return messages.ReplayControlMsg{Action: action}
+		}
+	}
+
+	switch action {
+	case keymap.ActionQuit:
+		return tea.Quit
+	case keymap.ActionToggleHelp:
+		return func() tea.Msg {
+			return messages.ShowHelpMsg{}
+		}
+	case keymap.ActionGoBack:
+		return func() tea.Msg {
+			return messages.GoBackMsg{}
+		}
+	case keymap.ActionNextTimeWindow:
+		sm.state.windowIndex = (sm.state.windowIndex + 1) % len(timeWindows)
+	case keymap.ActionToggleCPUSeries:
+		sm.state.series.cpu = !sm.state.series.cpu
+	case keymap.ActionToggleMEMSeries:
+		sm.state.series.mem = !sm.state.series.mem
+	case keymap.ActionToggleIOSeries:
+		sm.state.series.io = !sm.state.series.io
+	}
+	return nil
+}
+
+func (sm *stateManager) getWindow() time.Duration {
+	return timeWindows[sm.state.windowIndex]
+}
+
+// getSamples returns the history of the current target, oldest first
+func (sm *stateManager) getSamples() []history.Sample {
+	if sm.state.history == nil {
+		return nil
+	}
+	switch sm.state.target {
+	case messages.GraphProcess:
+		return sm.state.history.Process(sm.state.id)
+	case messages.GraphWorkspace:
+		return sm.state.history.Workspace(sm.state.id)
+	default:
+		return sm.state.history.System()
+	}
+}
@@ -0,0 +1,153 @@
+package processlist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+const coresPerRow = 8
+
+// affinityForm is the core picker of the CPU affinity dialog
+type affinityForm struct {
+	allowed []bool // indexed by CPU
+	cursor  int
+	onScope bool // focus is on the scope row below the cores
+	scope   scopePicker
+}
+
+func newAffinityForm(request ShowConfirmationMsg) affinityForm {
+	form := affinityForm{allowed: make([]bool, request.CPUs), scope: newScopePicker(request)}
+	for _, cpu := range request.Affinity {
+		if cpu < len(form.allowed) {
+			form.allowed[cpu] = true
+		}
+	}
+	return form
+}
+
+// handleKey moves the cursor, toggles cores and changes the scope, reporting
+// whether it used the key
+func (f *affinityForm) handleKey(key string) bool {
+	if len(f.allowed) == 0 {
+		return false
+	}
+	last := len(f.allowed) - 1
+	switch key {
+	case "up", "k":
+		if f.onScope {
+			f.onScope = false
+		} else if f.cursor >= coresPerRow {
+			f.cursor -= coresPerRow
+		}
+	case "down", "j":
+		if f.cursor+coresPerRow <= last {
+			f.cursor += coresPerRow
+		} else {
+			f.onScope = true
+		}
+	case "left", "h":
+		if f.onScope {
+			f.scope.move(-1)
+		} else {
+			f.cursor = max(f.cursor-1, 0)
+		}
+	case "right", "l":
+		if f.onScope {
+			f.scope.move(1)
+		} else {
+			f.cursor = min(f.cursor+1, last)
+		}
+	case " ", "x":
+		if !f.onScope {
+			f.allowed[f.cursor] = !f.allowed[f.cursor]
+		}
+	case "a":
+		// select every core, or none when all already are
+		all := f.count() < len(f.allowed)
+		for i := range f.allowed {
+			f.allowed[i] = all
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (f *affinityForm) cpus() []int {
+	var cpus []int
+	for cpu, ok := range f.allowed {
+		if ok {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus
+}
+
+func (f *affinityForm) count() int {
+	return len(f.cpus())
+}
+
+func (f *affinityForm) apply(action *messages.ProcessActionMsg) {
+	f.scope.apply(action)
+	action.CPUs = f.cpus()
+}
+
+func (f *affinityForm) view() string {
+	t := theme.Get().Dialog
+	if len(f.allowed) == 0 {
+		return t.Text.Render("The number of CPUs is not known yet")
+	}
+
+	var rows []string
+	for start := 0; start < len(f.allowed); start += coresPerRow {
+		var cells []string
+		for cpu := start; cpu < min(start+coresPerRow, len(f.allowed)); cpu++ {
+			mark := "○"
+			if f.allowed[cpu] {
+				mark = "●"
+			}
+			cell := fmt.Sprintf("%s %-3d", mark, cpu)
+			if cpu == f.cursor && !f.onScope {
+				cell = t.Cursor.Render(cell)
+			}
+			cells = append(cells, cell)
+		}
+		rows = append(rows, strings.Join(cells, " "))
+	}
+
+	scope := "  Apply " + f.scope.view()
+	if f.onScope {
+		scope = t.Cursor.Render("› Apply") + " " + f.scope.view()
+	}
+	rows = append(rows, "", scope, "", fmt.Sprintf("%d of %d cores: %s", f.count(), len(f.allowed), formatCPUList(f.cpus(), len(f.allowed))))
+	return strings.Join(rows, "\n")
+}
+
+// formatCPUList writes cpus in the kernel's list format, e.g. "0-3,6", or
+// "all" when it covers every one of total
+func formatCPUList(cpus []int, total int) string {
+	switch {
+	case len(cpus) == 0:
+		return "none"
+	case total > 0 && len(cpus) == total:
+		return "all"
+	}
+
+	var parts []string
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, fmt.Sprintf("%d", cpus[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
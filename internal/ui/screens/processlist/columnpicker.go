@@ -0,0 +1,181 @@
+package processlist
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+type pickerItem struct {
+	column  Column
+	enabled bool
+}
+
+// ColumnPicker is an overlay for showing, hiding and reordering table columns
+type ColumnPicker struct {
+	show   bool
+	items  []pickerItem
+	cursor int
+	width  int
+	height int
+}
+
+func NewColumnPicker() *ColumnPicker {
+	return &ColumnPicker{}
+}
+
+func (c *ColumnPicker) Init() tea.Cmd {
+	return nil
+}
+
+func (c *ColumnPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShowColumnPickerMsg:
+		c.Show(msg.Visible)
+		return c, nil
+	case tea.WindowSizeMsg:
+		c.width = msg.Width
+		c.height = msg.Height
+		return c, nil
+	}
+
+	if !c.show {
+		return c, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			c.cursor = max(c.cursor-1, 0)
+		case "down", "j":
+			c.cursor = min(c.cursor+1, len(c.items)-1)
+		case "shift+up", "K":
+			c.move(-1)
+		case "shift+down", "J":
+			c.move(1)
+		case " ", "space":
+			c.items[c.cursor].enabled = !c.items[c.cursor].enabled
+		case "enter":
+			ids := c.enabledIDs()
+			if len(ids) == 0 || !c.sortable() {
+				return c, nil
+			}
+			c.show = false
+			return c, func() tea.Msg {
+				return ColumnsChosenMsg{IDs: ids}
+			}
+		case "esc":
+			c.show = false
+		}
+	}
+
+	return c, nil
+}
+
+func (c *ColumnPicker) View() string {
+	if !c.show {
+		return ""
+	}
+
+	t := theme.Get().Dialog
+	titleStyle := t.Title.
+		MarginBottom(1)
+
+	lines := make([]string, len(c.items))
+	for i, item := range c.items {
+		check := "[ ]"
+		if item.enabled {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %-10s %s", check, item.column.ID, item.column.Header)
+		if i == c.cursor {
+			line = t.Cursor.Render("› " + line)
+		} else {
+			line = "  " + line
+		}
+		lines[i] = line
+	}
+
+	hint := t.Hint.
+		MarginTop(1).
+		Render("space: show/hide, shift+↑/↓: move, enter: apply (one column has to be sortable), esc: cancel")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Columns"),
+		strings.Join(lines, "\n"),
+		hint,
+	)
+
+	dialog := t.Box.Render(content)
+
+	return lipgloss.Place(c.width, c.height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+func (c *ColumnPicker) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// Show opens the picker with the visible columns first, in their current
+// order, followed by the hidden ones in registry order
+func (c *ColumnPicker) Show(visible []string) {
+	c.show = true
+	c.cursor = 0
+	c.items = c.items[:0]
+
+	enabled := make(map[string]bool, len(visible))
+	for _, id := range visible {
+		if col, ok := lookupColumn(id); ok {
+			c.items = append(c.items, pickerItem{column: col, enabled: true})
+			enabled[id] = true
+		}
+	}
+	for _, col := range columnRegistry {
+		if !enabled[col.ID] {
+			c.items = append(c.items, pickerItem{column: col})
+		}
+	}
+}
+
+func (c *ColumnPicker) move(delta int) {
+	target := c.cursor + delta
+	if target < 0 || target >= len(c.items) {
+		return
+	}
+	c.items[c.cursor], c.items[target] = c.items[target], c.items[c.cursor]
+	c.cursor = target
+}
+
+func (c *ColumnPicker) enabledIDs() []string {
+	var ids []string
+	for _, item := range c.items {
+		if item.enabled {
+			ids = append(ids, item.column.ID)
+		}
+	}
+	return ids
+}
+
+// sortable reports whether an enabled column can be sorted by, which the
+// sort keys need
+func (c *ColumnPicker) sortable() bool {
+	for _, item := range c.items {
+		if item.enabled && item.column.SortKey != viewmodel.SortByNone {
+			return true
+		}
+	}
+	return false
+}
+
+type ShowColumnPickerMsg struct {
+	Visible []string
+}
+
+type ColumnsChosenMsg struct {
+	IDs []string
+}
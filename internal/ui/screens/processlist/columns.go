@@ -0,0 +1,289 @@
+package processlist
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/sparkline"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+const (
+	trendWidth  = 10
+	cellPadding = 2 // the table pads every cell by one on each side
+)
+
+// Column describes one process table column. Columns are registered once and
+// referenced by ID from the config file and the column picker.
+type Column struct {
+	ID       string
+	Header   string
+	MinWidth int
+	Flex     int               // share of leftover width, 0 keeps MinWidth
+	SortKey  viewmodel.SortKey // SortByNone when the column is not sortable
+	value    func(proc taskmanager.TaskProcess, hist *history.Store) string
+}
+
+// newColumn pairs an extractor with a formatter for the extracted type
+func newColumn[T any](id, header string, minWidth, flex int, sortKey viewmodel.SortKey,
+	extract func(taskmanager.TaskProcess, *history.Store) T, format func(T) string) Column {
+	return Column{
+		ID:       id,
+		Header:   header,
+		MinWidth: minWidth,
+		Flex:     flex,
+		SortKey:  sortKey,
+		value: func(proc taskmanager.TaskProcess, hist *history.Store) string {
+			return format(extract(proc, hist))
+		},
+	}
+}
+
+// Value renders the cell of this column for proc
+func (c Column) Value(proc taskmanager.TaskProcess, hist *history.Store) string {
+	return c.value(proc, hist)
+}
+
+var columnRegistry = []Column{
+	newColumn("pid", "PID", 7, 0, viewmodel.SortByPID,
+		func(p taskmanager.TaskProcess, _ *history.Store) int { return p.PID }, formatInt),
+	newColumn("ppid", "PPID", 7, 0, viewmodel.SortByPPID,
+		func(p taskmanager.TaskProcess, _ *history.Store) int { return p.PPID }, formatInt),
+	newColumn("program", "Program", 12, 1, viewmodel.SortByProgramName,
+		func(p taskmanager.TaskProcess, _ *history.Store) string { return p.ProgramName }, formatString),
+	newColumn("user", "User", 8, 0, viewmodel.SortByUser,
+		func(p taskmanager.TaskProcess, _ *history.Store) string { return p.User }, formatString),
+	newColumn("state", "State", 10, 0, viewmodel.SortByState,
+		func(p taskmanager.TaskProcess, _ *history.Store) string { return p.State }, formatState),
+	newColumn("threads", "Thr", 5, 0, viewmodel.SortByThreads,
+		func(p taskmanager.TaskProcess, _ *history.Store) int { return p.Threads }, formatInt),
+	newColumn("nice", "NI", 4, 0, viewmodel.SortByNice,
+		func(p taskmanager.TaskProcess, _ *history.Store) int { return p.Nice }, formatInt),
+	newColumn("priority", "PRI", 4, 0, viewmodel.SortByPriority,
+		func(p taskmanager.TaskProcess, _ *history.Store) int { return p.Priority }, formatInt),
+	newColumn("elapsed", "Elapsed", 9, 0, viewmodel.SortByStartTime,
+		func(p taskmanager.TaskProcess, _ *history.Store) time.Time { return p.StartTime }, formatElapsed),
+	newColumn("tty", "TTY", 6, 0, viewmodel.SortByTTY,
+		func(p taskmanager.TaskProcess, _ *history.Store) string { return p.TTY }, formatTTY),
+	newColumn("command", "Command", 16, 3, viewmodel.SortByNone,
+		func(p taskmanager.TaskProcess, _ *history.Store) string { return p.CommandLine }, formatString),
+	newColumn("exe", "Exe", 12, 2, viewmodel.SortByExe,
+		func(p taskmanager.TaskProcess, _ *history.Store) string { return p.Exe }, formatString),
+	newColumn("workspace", "WS", 6, 0, viewmodel.SortByWorkspace,
+		func(p taskmanager.TaskProcess, _ *history.Store) *hypr.HyprlandMeta { return window(p) }, formatWorkspace),
+	newColumn("monitor", "Mon", 4, 0, viewmodel.SortByMonitor,
+		func(p taskmanager.TaskProcess, _ *history.Store) *hypr.HyprlandMeta { return window(p) }, formatMonitor),
+	newColumn("class", "Class", 10, 1, viewmodel.SortByClass,
+		func(p taskmanager.TaskProcess, _ *history.Store) *hypr.HyprlandMeta { return window(p) }, formatClass),
+	newColumn("cpu", "CPU%", 6, 0, viewmodel.SortByCPU,
+		func(p taskmanager.TaskProcess, _ *history.Store) float64 { return p.Metrics.CPU }, formatPercent),
+	newColumn("mem", "Mem%", 6, 0, viewmodel.SortByMEM,
+		func(p taskmanager.TaskProcess, _ *history.Store) float64 { return p.Metrics.MEM }, formatPercent),
+	newColumn("cpu_trend", "CPU Trend", trendWidth, 0, viewmodel.SortByNone,
+		func(p taskmanager.TaskProcess, hist *history.Store) []float64 {
+			return processHistory(p, hist, history.CPU)
+		}, formatTrend),
+	newColumn("mem_trend", "Mem Trend", trendWidth, 0, viewmodel.SortByNone,
+		func(p taskmanager.TaskProcess, hist *history.Store) []float64 {
+			return processHistory(p, hist, history.MEM)
+		}, formatTrend),
+}
+
+var defaultColumnIDs = []string{"pid", "program", "user", "command", "cpu", "mem", "cpu_trend", "mem_trend"}
+
+// DefaultColumnIDs lists the columns shown when none are configured
+func DefaultColumnIDs() []string {
+	return append([]string(nil), defaultColumnIDs...)
+}
+
+// ColumnIDs lists every registered column ID, for config validation
+func ColumnIDs() []string {
+	ids := make([]string, len(columnRegistry))
+	for i, col := range columnRegistry {
+		ids[i] = col.ID
+	}
+	return ids
+}
+
+// SortableColumnIDs lists the IDs of the columns the table can be sorted by,
+// for config validation
+func SortableColumnIDs() []string {
+	var ids []string
+	for _, col := range columnRegistry {
+		if col.SortKey != viewmodel.SortByNone {
+			ids = append(ids, col.ID)
+		}
+	}
+	return ids
+}
+
+func lookupColumn(id string) (Column, bool) {
+	for _, col := range columnRegistry {
+		if col.ID == id {
+			return col, true
+		}
+	}
+	return Column{}, false
+}
+
+// resolveColumns maps IDs to registered columns, dropping unknown IDs and
+// duplicates. Falls back to the defaults when nothing valid is left.
+func resolveColumns(ids []string) []Column {
+	seen := make(map[string]bool, len(ids))
+	columns := make([]Column, 0, len(ids))
+	for _, id := range ids {
+		col, ok := lookupColumn(id)
+		if !ok {
+			logger.Log.Warn("ignoring unknown process list column", "column", id)
+			continue
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return resolveColumns(defaultColumnIDs)
+	}
+	return columns
+}
+
+// layoutWidths fits the columns into totalWidth: every column gets its
+// minimum and the remainder is shared out by Flex weight
+func layoutWidths(columns []Column, totalWidth int) []int {
+	widths := make([]int, len(columns))
+	used, flexTotal := 0, 0
+	for i, col := range columns {
+		widths[i] = col.MinWidth
+		used += col.MinWidth + cellPadding
+		flexTotal += col.Flex
+	}
+
+	spare := totalWidth - used
+	if spare <= 0 || flexTotal == 0 {
+		return widths
+	}
+	given := 0
+	lastFlex := -1
+	for i, col := range columns {
+		if col.Flex == 0 {
+			continue
+		}
+		extra := spare * col.Flex / flexTotal
+		widths[i] += extra
+		given += extra
+		lastFlex = i
+	}
+	// Hand the rounding remainder to the last flexible column
+	widths[lastFlex] += spare - given
+	return widths
+}
+
+func processHistory(p taskmanager.TaskProcess, hist *history.Store, metric func(history.Sample) float64) []float64 {
+	if hist == nil {
+		return nil
+	}
+	return history.Values(hist.Process(p.PID), metric)
+}
+
+func formatInt(v int) string {
+	return fmt.Sprintf("%d", v)
+}
+
+func formatString(v string) string {
+	return v
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.1f", v)
+}
+
+func formatTrend(v []float64) string {
+	if v == nil {
+		return ""
+	}
+	return sparkline.Render(v, trendWidth, 0)
+}
+
+func formatTTY(tty string) string {
+	if tty == "" {
+		return "?"
+	}
+	return tty
+}
+
+// formatState spells out the state letter and flags the states that usually
+// need attention, since table cells cannot be colored individually
+func formatState(state string) string {
+	switch state {
+	case taskmanager.StateRunning:
+		return "R run"
+	case taskmanager.StateSleeping:
+		return "S sleep"
+	case taskmanager.StateUninterruptible:
+		return "D disk ⚠"
+	case taskmanager.StateZombie:
+		return "Z zombie ⚠"
+	case taskmanager.StateStopped:
+		return "T stopped"
+	case taskmanager.StateTracingStop:
+		return "t traced"
+	case taskmanager.StateIdle:
+		return "I idle"
+	default:
+		return state
+	}
+}
+
+func formatElapsed(start time.Time) string {
+	if start.IsZero() {
+		return "?"
+	}
+	d := time.Since(start)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%02dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+	default:
+		return fmt.Sprintf("%02d:%02d", minutes, seconds)
+	}
+}
+
+// window returns the Hyprland window of p, nil when it has none
+func window(p taskmanager.TaskProcess) *hypr.HyprlandMeta {
+	if p.Meta == nil {
+		return nil
+	}
+	return p.Meta.Hyprland
+}
+
+func formatWorkspace(w *hypr.HyprlandMeta) string {
+	if w == nil {
+		return "-"
+	}
+	return w.Workspace.Name
+}
+
+func formatMonitor(w *hypr.HyprlandMeta) string {
+	if w == nil {
+		return "-"
+	}
+	return formatInt(w.Monitor)
+}
+
+func formatClass(w *hypr.HyprlandMeta) string {
+	if w == nil {
+		return "-"
+	}
+	return w.Class
+}
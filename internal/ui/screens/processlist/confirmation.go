@@ -2,31 +2,70 @@ package processlist
 
 import (
 	"fmt"
+	"strings"
+	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
 )
 
+const (
+	maxListedTargets  = 8
+	maxListedFailures = 10
+)
+
+// signalChoices are the signals offered when the user picks one
+var signalChoices = []struct {
+	name   string
+	signal syscall.Signal
+}{
+	{"SIGTERM", syscall.SIGTERM},
+	{"SIGKILL", syscall.SIGKILL},
+	{"SIGHUP", syscall.SIGHUP},
+	{"SIGINT", syscall.SIGINT},
+	{"SIGQUIT", syscall.SIGQUIT},
+	{"SIGUSR1", syscall.SIGUSR1},
+	{"SIGUSR2", syscall.SIGUSR2},
+	{"SIGSTOP", syscall.SIGSTOP},
+	{"SIGCONT", syscall.SIGCONT},
+}
+
+func signalName(signal syscall.Signal) string {
+	for _, choice := range signalChoices {
+		if choice.signal == signal {
+			return choice.name
+		}
+	}
+	return signal.String()
+}
+
+type confirmationPhase int
+
+const (
+	phaseConfirm confirmationPhase = iota
+	phasePending                   // confirmed, waiting for the task manager
+	phaseResults
+)
+
+// ConfirmationScreen confirms an action on one or more processes and then
+// shows how it went for each of them
 type ConfirmationScreen struct {
-	show        bool
-	pid         int
-	processName string
-	command     string
-	force       bool
-	width       int
-	height      int
+	show         bool
+	phase        confirmationPhase
+	request      ShowConfirmationMsg
+	signalCursor int
+	priority     priorityForm
+	affinity     affinityForm
+	result       taskmanager.ActionResult
+	width        int
+	height       int
 }
 
 func NewConfirmationScreen() *ConfirmationScreen {
-	return &ConfirmationScreen{
-		show:        false,
-		pid:         0,
-		processName: "",
-		command:     "",
-		force:       false,
-		width:       0,
-		height:      0,
-	}
+	return &ConfirmationScreen{}
 }
 
 func (c *ConfirmationScreen) Init() tea.Cmd {
@@ -36,113 +75,266 @@ func (c *ConfirmationScreen) Init() tea.Cmd {
 func (c *ConfirmationScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ShowConfirmationMsg:
-		c.Show(msg.PID, msg.ProcessName, msg.Command, msg.Force)
+		c.Show(msg)
 		return c, nil
 	case tea.WindowSizeMsg:
 		c.width = msg.Width
 		c.height = msg.Height
 		return c, nil
+	case messages.ActionResultMsg:
+		if c.show && c.phase == phasePending {
+			c.result = msg.Result
+			c.phase = phaseResults
+		}
+		return c, nil
 	}
 
-	if !c.show {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if !c.show || !isKey {
 		return c, nil
 	}
 
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
-			c.show = false
-			return c, func() tea.Msg {
-				return ConfirmKillMsg{
-					PID:   c.pid,
-					Force: c.force,
-				}
-			}
-		case "esc":
+	switch c.phase {
+	case phaseConfirm:
+		return c, c.handleConfirmKey(keyMsg)
+	case phasePending:
+		if keyMsg.String() == "esc" {
 			c.show = false
-			return c, func() tea.Msg {
-				return CancelKillMsg{}
-			}
 		}
+	case phaseResults:
+		// any key closes the results
+		c.show = false
 	}
-
 	return c, nil
 }
 
+func (c *ConfirmationScreen) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		if c.request.Type == taskmanager.TaskActionAffinity && c.affinity.count() == 0 {
+			return nil
+		}
+		c.phase = phasePending
+		action := c.processAction()
+		return func() tea.Msg {
+			return ConfirmBatchMsg{Action: action}
+		}
+	case "esc":
+		c.show = false
+	case "up", "k":
+		if c.request.ChooseSignal {
+			c.signalCursor = max(c.signalCursor-1, 0)
+		}
+	case "down", "j":
+		if c.request.ChooseSignal {
+			c.signalCursor = min(c.signalCursor+1, len(signalChoices)-1)
+		}
+	}
+	if c.isPriority() {
+		c.priority.handleKey(msg.String())
+	}
+	if c.request.Type == taskmanager.TaskActionAffinity {
+		c.affinity.handleKey(msg.String())
+	}
+	return nil
+}
+
+// isPriority reports whether the request is set through the priority sliders
+func (c *ConfirmationScreen) isPriority() bool {
+	return c.request.Type == taskmanager.TaskActionRenice || c.request.Type == taskmanager.TaskActionIOPriority
+}
+
+// processAction is the request for the task manager, as currently configured
+func (c *ConfirmationScreen) processAction() messages.ProcessActionMsg {
+	pids := make([]int, len(c.request.Targets))
+	for i, target := range c.request.Targets {
+		pids[i] = target.PID
+	}
+	action := messages.ProcessActionMsg{Type: c.request.Type, PIDs: pids, Signal: c.signal()}
+	if c.isPriority() {
+		c.priority.apply(&action)
+	}
+	if c.request.Type == taskmanager.TaskActionAffinity {
+		c.affinity.apply(&action)
+	}
+	return action
+}
+
+func (c *ConfirmationScreen) signal() syscall.Signal {
+	if c.request.ChooseSignal {
+		return signalChoices[c.signalCursor].signal
+	}
+	return c.request.Signal
+}
+
 func (c *ConfirmationScreen) View() string {
 	if !c.show {
 		return ""
 	}
 
-	killType := "SIGTERM"
-	if c.force {
-		killType = "SIGKILL (force)"
-	}
-
-	title := "Kill Process?"
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("205")).
-		Align(lipgloss.Center).
-		MarginBottom(1)
-
-	pidText := fmt.Sprintf("PID: %d", c.pid)
-	processText := fmt.Sprintf("Process: %s", c.processName)
-	commandText := fmt.Sprintf("Command: %s", c.command)
-	signalText := fmt.Sprintf("Signal: %s", killType)
-
-	infoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252")).
-		MarginBottom(1)
-
-	confirmText := "Press Enter to confirm, Esc to cancel"
-	confirmStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("243")).
-		Italic(true).
-		MarginTop(1)
-
-	content := lipgloss.JoinVertical(
-		lipgloss.Center,
-		titleStyle.Render(title),
-		infoStyle.Render(pidText),
-		infoStyle.Render(processText),
-		infoStyle.Render(commandText),
-		infoStyle.Render(signalText),
-		confirmStyle.Render(confirmText),
-	)
+	var content string
+	switch c.phase {
+	case phaseConfirm:
+		content = c.confirmView()
+	case phasePending:
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			theme.Get().Dialog.Title.MarginBottom(1).Render(c.title()),
+			theme.Get().Dialog.Text.Render("Working…"),
+		)
+	case phaseResults:
+		content = c.resultsView()
+	}
 
 	dialogWidth := 60
 	if c.width > 0 && c.width < dialogWidth {
 		dialogWidth = c.width - 4
 	}
+	dialog := theme.Get().Dialog.Box.Width(dialogWidth).Render(content)
 
-	dialogStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("205")).
-		Padding(1, 2).
-		Width(dialogWidth).
-		Align(lipgloss.Center)
+	return lipgloss.Place(c.width, c.height, lipgloss.Center, lipgloss.Center, dialog)
+}
 
-	dialog := dialogStyle.Render(content)
+func (c *ConfirmationScreen) confirmView() string {
+	t := theme.Get().Dialog
+	blocks := []string{
+		t.Title.MarginBottom(1).Render(c.title()),
+		t.Text.Render(c.targetList()),
+	}
 
-	centered := lipgloss.Place(
-		c.width,
-		c.height,
-		lipgloss.Center,
-		lipgloss.Center,
-		dialog,
-	)
+	hint := "enter: confirm, esc: cancel"
+	switch {
+	case c.request.ChooseSignal:
+		lines := make([]string, len(signalChoices))
+		for i, choice := range signalChoices {
+			if i == c.signalCursor {
+				lines[i] = t.Cursor.Render("› " + choice.name)
+			} else {
+				lines[i] = "  " + choice.name
+			}
+		}
+		blocks = append(blocks, lipgloss.NewStyle().MarginTop(1).Render(strings.Join(lines, "\n")))
+		hint = "↑/↓: signal, " + hint
+	case c.isPriority():
+		blocks = append(blocks,
+			lipgloss.NewStyle().MarginTop(1).Render(c.priority.view()),
+			t.Hint.MarginTop(1).Render(c.priority.hint()))
+		hint = "↑/↓: choose, ←/→: change, " + hint
+	case c.request.Type == taskmanager.TaskActionAffinity:
+		blocks = append(blocks,
+			lipgloss.NewStyle().MarginTop(1).Render(c.affinity.view()),
+			t.Hint.MarginTop(1).Render(scopeHint))
+		hint = "space: toggle, a: all, " + hint
+	}
+
+	blocks = append(blocks, t.Hint.MarginTop(1).Render(hint))
+	return lipgloss.JoinVertical(lipgloss.Left, blocks...)
+}
+
+func (c *ConfirmationScreen) title() string {
+	subject := c.subject()
+	switch c.request.Type {
+	case taskmanager.TaskActionRenice:
+		return "Renice " + subject
+	case taskmanager.TaskActionIOPriority:
+		return "Set the I/O priority of " + subject
+	case taskmanager.TaskActionAffinity:
+		return "Choose the CPUs of " + subject
+	case taskmanager.TaskActionCloseWindow:
+		return fmt.Sprintf("Close the windows of %s?", subject)
+	default:
+		if c.request.ChooseSignal {
+			return "Send a signal to " + subject
+		}
+		return fmt.Sprintf("Send %s to %s?", signalName(c.request.Signal), subject)
+	}
+}
+
+// subject names the single target, or counts them
+func (c *ConfirmationScreen) subject() string {
+	if len(c.request.Targets) == 1 {
+		target := c.request.Targets[0]
+		return fmt.Sprintf("%s (%d)", target.Name, target.PID)
+	}
+	return fmt.Sprintf("%d processes", len(c.request.Targets))
+}
+
+func (c *ConfirmationScreen) targetList() string {
+	var lines []string
+	targets := c.targets()
+	for i, target := range targets {
+		if i == maxListedTargets {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(targets)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%7d  %s", target.PID, target.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *ConfirmationScreen) resultsView() string {
+	t := theme.Get().Dialog
+	failed := c.result.Failed()
+	succeeded := len(c.result.Items) - failed
+
+	title := "Done"
+	if failed > 0 {
+		title = fmt.Sprintf("%d of %d failed", failed, len(c.result.Items))
+	}
+
+	names := make(map[int]string)
+	for _, target := range c.targets() {
+		names[target.PID] = target.Name
+	}
+	lines := []string{fmt.Sprintf("%d succeeded, %d failed", succeeded, failed)}
+	listed := 0
+	for _, item := range c.result.Items {
+		if item.Err == nil {
+			continue
+		}
+		if listed == maxListedFailures {
+			lines = append(lines, fmt.Sprintf("… and %d more", failed-listed))
+			break
+		}
+		name, ok := names[item.PID]
+		if !ok {
+			// a child picked up by a tree scope
+			name = "child"
+		}
+		lines = append(lines, fmt.Sprintf("%s (%d): %v", name, item.PID, item.Err))
+		listed++
+	}
 
-	return centered
+	return lipgloss.JoinVertical(lipgloss.Left,
+		t.Title.MarginBottom(1).Render(title),
+		t.Text.Render(strings.Join(lines, "\n")),
+		t.Hint.MarginTop(1).Render("press any key to close"),
+	)
 }
 
-func (c *ConfirmationScreen) Show(pid int, processName, command string, force bool) {
+// Show opens the dialog for a new request
+func (c *ConfirmationScreen) Show(request ShowConfirmationMsg) {
 	c.show = true
-	c.pid = pid
-	c.processName = processName
-	c.command = command
-	c.force = force
+	c.phase = phaseConfirm
+	c.request = request
+	c.priority = newPriorityForm(request)
+	c.affinity = newAffinityForm(request)
+	c.signalCursor = 0
+	for i, choice := range signalChoices {
+		if choice.signal == request.Signal {
+			c.signalCursor = i
+		}
+	}
+}
+
+// targets are the processes the action is applied to, as currently configured
+func (c *ConfirmationScreen) targets() []Target {
+	if c.isPriority() {
+		return c.priority.scope.targets()
+	}
+	if c.request.Type == taskmanager.TaskActionAffinity {
+		return c.affinity.scope.targets()
+	}
+	return c.request.Targets
 }
 
 func (c *ConfirmationScreen) Hide() {
@@ -154,16 +346,31 @@ func (c *ConfirmationScreen) SetSize(width, height int) {
 	c.height = height
 }
 
+// Target is one process a confirmed action applies to
+type Target struct {
+	PID  int
+	Name string
+}
+
 type ShowConfirmationMsg struct {
-	PID         int
-	ProcessName string
-	Command     string
-	Force       bool
+	Type         taskmanager.TaskActionType
+	Signal       syscall.Signal // the signal to send, or the preselected one when ChooseSignal is set
+	ChooseSignal bool
+	Nice         int               // starting value for renice
+	Workspace    *WorkspaceTargets // offered as a scope of priority and affinity actions, nil without a window
+	CPUs         int               // number of CPUs, for affinity
+	Affinity     []int             // starting set for affinity
+	Threads      []Target          // offered one by one as a scope of affinity, nil unless one process has several
+	Targets      []Target
 }
 
-type ConfirmKillMsg struct {
-	PID   int
-	Force bool
+// WorkspaceTargets are the windowed processes on one workspace
+type WorkspaceTargets struct {
+	Name    string
+	Targets []Target
 }
 
-type CancelKillMsg struct{}
+// ConfirmBatchMsg carries a confirmed action on its way to the task manager
+type ConfirmBatchMsg struct {
+	Action messages.ProcessActionMsg
+}
@@ -0,0 +1,159 @@
+package processlist
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+// ProcessDetails is an overlay listing every field of one process
+type ProcessDetails struct {
+	show   bool
+	proc   taskmanager.TaskProcess
+	cpus   int
+	width  int
+	height int
+}
+
+func NewProcessDetails() *ProcessDetails {
+	return &ProcessDetails{}
+}
+
+func (d *ProcessDetails) Init() tea.Cmd {
+	return nil
+}
+
+func (d *ProcessDetails) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShowDetailsMsg:
+		d.Show(msg.Process, msg.CPUs)
+		return d, nil
+	case tea.WindowSizeMsg:
+		d.SetSize(msg.Width, msg.Height)
+		return d, nil
+	}
+
+	if !d.show {
+		return d, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "enter", "q":
+			d.show = false
+		}
+	case tea.MouseMsg:
+		if msg.Action == tea.MouseActionPress && !tea.MouseEvent(msg).IsWheel() {
+			d.show = false
+		}
+	}
+
+	return d, nil
+}
+
+func (d *ProcessDetails) View() string {
+	if !d.show {
+		return ""
+	}
+
+	t := theme.Get().Dialog
+	p := d.proc
+	fields := []struct {
+		label string
+		value string
+	}{
+		{"PID", formatInt(p.PID)},
+		{"PPID", formatInt(p.PPID)},
+		{"Program", p.ProgramName},
+		{"User", p.User},
+		{"State", formatState(p.State)},
+		{"Threads", formatInt(p.Threads)},
+		{"Nice", formatInt(p.Nice)},
+		{"Priority", formatInt(p.Priority)},
+		{"Affinity", formatAffinity(p.Affinity, d.cpus)},
+		{"Elapsed", formatElapsed(p.StartTime)},
+		{"TTY", formatTTY(p.TTY)},
+		{"CPU", formatPercent(p.Metrics.CPU) + "%"},
+		{"Memory", formatPercent(p.Metrics.MEM) + "%"},
+		{"IO read", formatRate(p.Metrics.IORead)},
+		{"IO write", formatRate(p.Metrics.IOWrite)},
+		{"Exe", p.Exe},
+		{"Command", p.CommandLine},
+	}
+
+	dialogWidth := 72
+	if d.width > 0 && d.width-4 < dialogWidth {
+		dialogWidth = d.width - 4
+	}
+	// Box border and padding take four columns, the label column twelve
+	valueWidth := max(dialogWidth-4-12, 10)
+
+	lines := make([]string, len(fields))
+	for i, field := range fields {
+		value := field.value
+		if value == "" {
+			value = "?"
+		}
+		lines[i] = fmt.Sprintf("%-11s %s", field.label, truncate(value, valueWidth))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		t.Title.MarginBottom(1).Render(fmt.Sprintf("%s (%d)", p.ProgramName, p.PID)),
+		t.Text.Render(strings.Join(lines, "\n")),
+		t.Hint.MarginTop(1).Render("esc: close"),
+	)
+
+	dialog := t.Box.Width(dialogWidth).Render(content)
+
+	return lipgloss.Place(d.width, d.height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+func (d *ProcessDetails) Show(proc taskmanager.TaskProcess, cpus int) {
+	d.show = true
+	d.proc = proc
+	d.cpus = cpus
+}
+
+func (d *ProcessDetails) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+}
+
+func formatRate(v float64) string {
+	const unit = 1024
+	if v < unit {
+		return fmt.Sprintf("%.0fB/s", v)
+	}
+	div, exp := float64(unit), 0
+	for n := v / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB/s", v/div, "KMGTPE"[exp])
+}
+
+// truncate shortens s to width cells, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+type ShowDetailsMsg struct {
+	Process taskmanager.TaskProcess
+	CPUs    int // number of CPUs, to tell a pinned affinity from the full set
+}
+
+func formatAffinity(cpus []int, total int) string {
+	if cpus == nil {
+		return "-"
+	}
+	return formatCPUList(cpus, total)
+}
@@ -0,0 +1,98 @@
+package processlist
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+// FilterPrompt asks for the text that processes to mark must contain
+type FilterPrompt struct {
+	show   bool
+	input  textinput.Model
+	width  int
+	height int
+}
+
+func NewFilterPrompt() *FilterPrompt {
+	input := textinput.New()
+	input.Placeholder = "program or command"
+	input.Prompt = "/ "
+	input.CharLimit = 128
+	return &FilterPrompt{input: input}
+}
+
+func (f *FilterPrompt) Init() tea.Cmd {
+	return nil
+}
+
+func (f *FilterPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShowFilterPromptMsg:
+		f.show = true
+		f.input.SetValue("")
+		return f, f.input.Focus()
+	case tea.WindowSizeMsg:
+		f.SetSize(msg.Width, msg.Height)
+		return f, nil
+	}
+
+	if !f.show {
+		return f, nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "enter":
+			f.show = false
+			f.input.Blur()
+			query := f.input.Value()
+			if query == "" {
+				return f, nil
+			}
+			return f, func() tea.Msg {
+				return MarkMatchingMsg{Query: query}
+			}
+		case "esc":
+			f.show = false
+			f.input.Blur()
+			return f, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return f, cmd
+}
+
+func (f *FilterPrompt) View() string {
+	if !f.show {
+		return ""
+	}
+
+	t := theme.Get().Dialog
+	f.input.Width = max(min(f.width-12, 48), 10)
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		t.Title.MarginBottom(1).Render("Mark processes matching"),
+		f.input.View(),
+		t.Hint.MarginTop(1).Render("enter: mark, esc: cancel"),
+	)
+
+	dialog := t.Box.Render(content)
+
+	return lipgloss.Place(f.width, f.height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+func (f *FilterPrompt) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+type ShowFilterPromptMsg struct{}
+
+// MarkMatchingMsg marks every listed process whose program or command contains Query
+type MarkMatchingMsg struct {
+	Query string
+}
@@ -0,0 +1,114 @@
+package processlist
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+const (
+	collapsedPrefix = "▸ "
+	expandedPrefix  = "▾ "
+	memberIndent    = "  "
+)
+
+// listRow is one line of the table: a process, or a group of them while grouping
+type listRow struct {
+	proc   taskmanager.TaskProcess // the group's aggregate for group rows
+	group  *viewmodel.ProcessGroup // nil for process rows
+	member bool                    // listed under its expanded group
+}
+
+// buildListRows lays out the processes, or the groups followed by the members
+// of the expanded ones
+func (sm *stateManager) buildListRows() {
+	if sm.state.groupBy == viewmodel.GroupByNone || sm.state.groups == nil {
+		sm.state.rows = make([]listRow, len(sm.state.processList))
+		for i, proc := range sm.state.processList {
+			sm.state.rows[i] = listRow{proc: proc}
+		}
+		return
+	}
+
+	sm.state.rows = sm.state.rows[:0]
+	for i := range sm.state.groups {
+		group := &sm.state.groups[i]
+		sm.state.rows = append(sm.state.rows, listRow{proc: group.Aggregate, group: group})
+		if sm.state.expanded[group.Key] {
+			for _, member := range group.Members {
+				sm.state.rows = append(sm.state.rows, listRow{proc: member, member: true})
+			}
+		}
+	}
+}
+
+func (sm *stateManager) getRows() []listRow {
+	return sm.state.rows
+}
+
+// sameAs reports whether other shows the same group, or the same process at
+// the same level
+func (row listRow) sameAs(other listRow) bool {
+	if row.group != nil || other.group != nil {
+		return row.group != nil && other.group != nil && row.group.Key == other.group.Key
+	}
+	return row.proc.PID == other.proc.PID && row.member == other.member
+}
+
+func (sm *stateManager) selectedRow() (listRow, bool) {
+	return sm.state.selectedRow()
+}
+
+func (st *state) selectedRow() (listRow, bool) {
+	if st.cursor < 0 || st.cursor >= len(st.rows) {
+		return listRow{}, false
+	}
+	return st.rows[st.cursor], true
+}
+
+// toggleGroup expands or collapses the selected group, reporting whether a group was selected
+func (sm *stateManager) toggleGroup() bool {
+	row, ok := sm.selectedRow()
+	if !ok || row.group == nil {
+		return false
+	}
+	key := row.group.Key
+	if sm.state.expanded[key] {
+		delete(sm.state.expanded, key)
+	} else {
+		sm.state.expanded[key] = true
+	}
+	sm.buildListRows()
+	return true
+}
+
+// cycleGrouping asks the view model for the next grouping
+func (sm *stateManager) cycleGrouping() tea.Cmd {
+	next := sm.state.groupBy.Next()
+	return func() tea.Msg {
+		return messages.ChangeGroupByMsg{GroupBy: next}
+	}
+}
+
+// labelColumn is the index of the column that names groups: the program
+// column when shown, the first one otherwise
+func labelColumn(columns []Column) int {
+	for i, col := range columns {
+		if col.ID == "program" {
+			return i
+		}
+	}
+	return 0
+}
+
+// groupLabel names a group row in its label column
+func groupLabel(group *viewmodel.ProcessGroup, expanded bool) string {
+	prefix := collapsedPrefix
+	if expanded {
+		prefix = expandedPrefix
+	}
+	return fmt.Sprintf("%s%s (%d)", prefix, group.Key, len(group.Members))
+}
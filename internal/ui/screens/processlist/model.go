@@ -2,62 +2,73 @@ package processlist
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/history"
 	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/hintbar"
 	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
 	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/ui/mouse"
 	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
 	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
 )
 
 type ProcessList struct {
 	stateManager *stateManager
 	table        table.Model
-	confirmation  *ConfirmationScreen
+	confirmation *ConfirmationScreen
+	columnPicker *ColumnPicker
+	details      *ProcessDetails
+	filterPrompt *FilterPrompt
+	clicks       mouse.ClickTracker
+	layout       tableLayout
+	pinnedCores  []int // last reported to the usage bars
 	width        int
 	height       int
 }
 
-func NewProcessList(procs []taskmanager.TaskProcess) *ProcessList {
-	columns := []table.Column{
-		{Title: "PID", Width: 8},
-		{Title: "Program", Width: 20},
-		{Title: "User", Width: 12},
-		{Title: "Command", Width: 30},
-		{Title: "CPU%", Width: 8},
-		{Title: "Mem%", Width: 8},
+// NewProcessList creates the process table showing columnIDs in order, or the
+// default columns when empty. sort must match the view model's initial options.
+func NewProcessList(procs []taskmanager.TaskProcess, columnIDs []string, sort viewmodel.ViewOptions) *ProcessList {
+	sm := newStateManager(procs, columnIDs, sort, nil)
+	visible := sm.visibleColumns()
+	columns := make([]table.Column, len(visible))
+	for i, col := range visible {
+		columns[i] = table.Column{Title: col.Header, Width: col.MinWidth}
 	}
 	
-	rows := make([]table.Row, len(procs))
-	for i, proc := range procs {
-		rows[i] = table.Row{
-			fmt.Sprintf("%d", proc.PID),
-			proc.ProgramName,
-			proc.User,
-			proc.CommandLine,
-			fmt.Sprintf("%.1f", proc.Metrics.CPU),
-			fmt.Sprintf("%.1f", proc.Metrics.MEM),
-		}
-	}
+	rows := buildRows(sm.getRows(), visible, nil, nil, nil)
 	
-	styles := table.DefaultStyles()
-	styles.Header = styles.Header.Align(lipgloss.Center)
-	styles.Cell = styles.Cell.Align(lipgloss.Center)
+	pt := theme.Get().ProcessView
+	styles := table.Styles{
+		Header:   pt.HeaderText.Align(lipgloss.Center),
+		Cell:     pt.Row.Align(lipgloss.Center),
+		Selected: pt.SelectedRow,
+	}
 	
 	t := table.New(
 		table.WithColumns(columns),
 		table.WithRows(rows),
 		table.WithFocused(true),
 		table.WithStyles(styles),
+		// Movement goes through the app key map so rebinding and presets apply to the table too
+		table.WithKeyMap(table.KeyMap{}),
 	)
 	
+	sm.updateTable(&t)
 	return &ProcessList{
-		stateManager: newStateManager(procs, &t),
+		stateManager: sm,
 		table:        t,
 		confirmation: NewConfirmationScreen(),
+		columnPicker: NewColumnPicker(),
+		details:      NewProcessDetails(),
+		filterPrompt: NewFilterPrompt(),
 	}
 }
 
@@ -67,18 +78,64 @@ func (p *ProcessList) Init() tea.Cmd {
 
 func (p *ProcessList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch typedMsg := msg.(type) {
-	case ConfirmKillMsg:
+	case ConfirmBatchMsg:
+		p.stateManager.clearMarks()
+		p.refreshRows()
 		return p, func() tea.Msg {
-			return messages.NewKillProcessMsg(typedMsg.PID, typedMsg.Force)
+			return typedMsg.Action
 		}
-	case CancelKillMsg:
+	case messages.ActionResultMsg:
+		updatedConfirmation, cmd := p.confirmation.Update(msg)
+		p.confirmation = updatedConfirmation.(*ConfirmationScreen)
+		return p, cmd
+	case rowsChangedMsg:
+		p.refreshRows()
+		return p, nil
+	case MarkMatchingMsg:
+		p.stateManager.markMatching(typedMsg.Query)
+		p.refreshRows()
 		return p, nil
+	case ColumnsChosenMsg:
+		p.stateManager.setColumns(typedMsg.IDs)
+		p.refreshRows()
+		ids := p.stateManager.visibleColumnIDs()
+		return p, func() tea.Msg {
+			return messages.SaveColumnsMsg{Columns: ids}
+		}
+	}
+
+	if p.details.show {
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			updatedDetails, cmd := p.details.Update(msg)
+			p.details = updatedDetails.(*ProcessDetails)
+			return p, cmd
+		}
+	}
+
+	if p.columnPicker.show {
+		if _, isKey := msg.(tea.KeyMsg); isKey {
+			updatedPicker, cmd := p.columnPicker.Update(msg)
+			p.columnPicker = updatedPicker.(*ColumnPicker)
+			return p, cmd
+		}
 	}
 	
 	if p.confirmation.show {
-		updatedConfirmation, cmd := p.confirmation.Update(msg)
-		p.confirmation = updatedConfirmation.(*ConfirmationScreen)
-		if cmd != nil {
+		if _, isKey := msg.(tea.KeyMsg); isKey {
+			updatedConfirmation, cmd := p.confirmation.Update(msg)
+			p.confirmation = updatedConfirmation.(*ConfirmationScreen)
+			return p, cmd
+		}
+	}
+
+	if p.filterPrompt.show {
+		switch msg.(type) {
+		case messages.ProcessListMsg, tea.WindowSizeMsg:
+		default:
+			// The prompt also needs its cursor blink messages
+			updatedPrompt, cmd := p.filterPrompt.Update(msg)
+			p.filterPrompt = updatedPrompt.(*FilterPrompt)
 			return p, cmd
 		}
 	}
@@ -86,8 +143,23 @@ func (p *ProcessList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch typedMsg := msg.(type) {
 	case messages.ProcessListMsg:
 		p.stateManager.setState(typedMsg)
-		p.updateTableWithProcesses(p.stateManager.getProcs())
-		return p, nil
+		p.refreshRows()
+		return p, p.reportPinnedCores()
+	case ShowColumnPickerMsg:
+		p.columnPicker.SetSize(p.width, p.height)
+		updatedPicker, cmd := p.columnPicker.Update(msg)
+		p.columnPicker = updatedPicker.(*ColumnPicker)
+		return p, cmd
+	case ShowFilterPromptMsg:
+		p.filterPrompt.SetSize(p.width, p.height)
+		updatedPrompt, cmd := p.filterPrompt.Update(msg)
+		p.filterPrompt = updatedPrompt.(*FilterPrompt)
+		return p, cmd
+	case ShowDetailsMsg:
+		p.details.SetSize(p.width, p.height)
+		updatedDetails, cmd := p.details.Update(msg)
+		p.details = updatedDetails.(*ProcessDetails)
+		return p, cmd
 	case ShowConfirmationMsg:
 		p.confirmation.SetSize(p.width, p.height)
 		updatedConfirmation, cmd := p.confirmation.Update(msg)
@@ -97,19 +169,36 @@ func (p *ProcessList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		p.handleWindowSize(typedMsg)
 		updatedConfirmation, _ := p.confirmation.Update(msg)
 		p.confirmation = updatedConfirmation.(*ConfirmationScreen)
+		p.columnPicker.SetSize(p.width, p.height)
+		p.details.SetSize(p.width, p.height)
+		p.filterPrompt.SetSize(p.width, p.height)
 		return p, nil
 	case tea.KeyMsg:
-		updatedTable, cmd := p.table.Update(msg)
-		p.table = updatedTable
 		p.stateManager.updateTable(&p.table)
-		if cmd != nil {
-			return p, cmd
+		return p, tea.Batch(p.stateManager.handleKeyMsg(typedMsg), p.reportPinnedCores())
+	case tea.MouseMsg:
+		if p.confirmation.show || p.columnPicker.show || p.filterPrompt.show {
+			return p, nil
 		}
-		return p, p.stateManager.handleKeyMsg(typedMsg)
+		p.stateManager.updateTable(&p.table)
+		return p, tea.Batch(p.handleMouse(typedMsg), p.reportPinnedCores())
 	}
 	return p, nil
 }
 
+// reportPinnedCores tells the usage bars when the cores the selected process
+// is pinned to changed
+func (p *ProcessList) reportPinnedCores() tea.Cmd {
+	cores := p.stateManager.pinnedCores()
+	if slices.Equal(cores, p.pinnedCores) {
+		return nil
+	}
+	p.pinnedCores = cores
+	return func() tea.Msg {
+		return messages.PinnedCoresMsg{Cores: cores}
+	}
+}
+
 func (p *ProcessList) View() string {
 	wsName := p.stateManager.getWorkspaceName()
 	wsNameStr := "all processes"
@@ -117,22 +206,27 @@ func (p *ProcessList) View() string {
 		wsNameStr = "workspace " + *wsName
 	}
 
+	t := theme.Get().ProcessView
 	title := fmt.Sprintf("Process List for %s", wsNameStr)
-	header := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("205")).
-		Render(title)
+	if groupBy := p.stateManager.state.groupBy; groupBy != viewmodel.GroupByNone {
+		title += fmt.Sprintf(" · by %s", groupBy)
+	}
+	if marked := len(p.stateManager.getMarks()); marked > 0 {
+		title += fmt.Sprintf(" · %d marked", marked)
+	}
+	header := t.Title.Render(title)
+	if alert := p.stateAlert(); alert != "" {
+		header += "  " + t.Alert.Render(alert)
+	}
 
 	p.updateColumnHeaders()
 	
 	tableView := p.table.View()
-	tableHelp := "Table Help: " + p.table.HelpView()
 	
-	instructions := keymap.Get().GetHelpText(screens.ProcessList)
+	instructions := hintbar.Render(keymap.Get().ShortHelp(screens.ProcessList), p.width)
 
 	centeredHeader := lipgloss.PlaceHorizontal(p.width, lipgloss.Center, header)
 	centeredTable := lipgloss.PlaceHorizontal(p.width, lipgloss.Center, tableView)
-	centeredHelp := lipgloss.PlaceHorizontal(p.width, lipgloss.Center, tableHelp)
 	centeredInstructions := lipgloss.PlaceHorizontal(p.width, lipgloss.Center, instructions)
 
 	var marginTop, marginBottom int
@@ -143,35 +237,38 @@ func (p *ProcessList) View() string {
 
 	headerStyled := lipgloss.NewStyle().MarginTop(marginTop).Render(centeredHeader)
 	tableStyled := lipgloss.NewStyle().MarginTop(marginTop).MarginBottom(marginBottom).Render(centeredTable)
-	helpStyled := lipgloss.NewStyle().MarginBottom(marginBottom).Render(centeredHelp)
 	instructionsStyled := lipgloss.NewStyle().Render(centeredInstructions)
 
-	processListView := lipgloss.JoinVertical(lipgloss.Center, headerStyled, tableStyled, helpStyled, instructionsStyled)
+	processListView := lipgloss.JoinVertical(lipgloss.Center, headerStyled, tableStyled, instructionsStyled)
+
+	p.layout = tableLayout{
+		top:          lipgloss.Height(headerStyled) + marginTop,
+		left:         max(p.width-lipgloss.Width(tableView), 0) / 2,
+		headerHeight: lipgloss.Height(tableView) - p.table.Height(),
+	}
 
 	if p.confirmation.show {
 		return p.confirmation.View()
 	}
+	if p.columnPicker.show {
+		return p.columnPicker.View()
+	}
+	if p.details.show {
+		return p.details.View()
+	}
+	if p.filterPrompt.show {
+		return p.filterPrompt.View()
+	}
 
 	return processListView
 }
 
-func (p *ProcessList) updateTableWithProcesses(procs []taskmanager.TaskProcess) {
-	rows := make([]table.Row, len(procs))
-	for i, proc := range procs {
-		rows[i] = table.Row{
-			fmt.Sprintf("%d", proc.PID),
-			proc.ProgramName,
-			proc.User,
-			proc.CommandLine,
-			fmt.Sprintf("%.1f", proc.Metrics.CPU),
-			fmt.Sprintf("%.1f", proc.Metrics.MEM),
-		}
-	}
-	
-	p.table.SetRows(rows)
+// refreshRows re-renders the rows of the current processes, e.g. after marking
+func (p *ProcessList) refreshRows() {
 	p.updateColumnHeaders()
-	p.table.Focus()
 	p.stateManager.updateTable(&p.table)
+	p.stateManager.syncTable()
+	p.table.Focus()
 }
 
 func (p *ProcessList) handleWindowSize(msg tea.WindowSizeMsg) {
@@ -187,59 +284,109 @@ func (p *ProcessList) handleWindowSize(msg tea.WindowSizeMsg) {
 	}
 	
 	p.table.SetHeight(tableHeight)
+	p.updateColumnHeaders()
+	p.stateManager.syncTable()
 }
 
 func (p *ProcessList) updateColumnHeaders() {
-	sortKey := p.stateManager.state.sortOptions.key
-	sortOrder := p.stateManager.state.sortOptions.order
-	
-	currentColumns := p.table.Columns()
-	baseTitles := []string{"PID", "Program", "User", "Command", "CPU%", "Mem%"}
-	
-	var arrow string
-	switch sortOrder {
-	case viewmodel.OrderASC:
-		arrow = " ↑"
-	case viewmodel.OrderDESC:
-		arrow = " ↓"
-	default:
-		arrow = ""
-	}
-	
-	columnIndex := p.getColumnIndexForSortKey(sortKey)
-	
-	newColumns := make([]table.Column, len(currentColumns))
-	for i := range currentColumns {
-		if i < len(baseTitles) {
-			title := baseTitles[i]
-			if sortKey != viewmodel.SortByNone && i == columnIndex {
-				title = baseTitles[i] + arrow
-			}
-			newColumns[i] = table.Column{
-				Title: title,
-				Width: currentColumns[i].Width,
+	// Number the arrows once there are tie-breakers
+	numbered := len(p.stateManager.state.sortOptions.thenBy) > 0
+
+	visible := p.stateManager.visibleColumns()
+	widths := layoutWidths(visible, p.width)
+	newColumns := make([]table.Column, len(visible))
+	for i, col := range visible {
+		title := col.Header
+		if order, rank := p.stateManager.sortRank(col.SortKey); rank > 0 {
+			title += sortArrow(order)
+			if numbered {
+				title += sortRankMarks[min(rank, len(sortRankMarks))-1]
 			}
-		} else {
-			newColumns[i] = currentColumns[i]
+		}
+		newColumns[i] = table.Column{
+			Title: title,
+			Width: widths[i],
 		}
 	}
 	
+	// Shrinking the column set with stale rows would index out of range while rendering
+	if len(newColumns) != len(p.table.Columns()) {
+		p.table.SetRows(nil)
+	}
 	p.table.SetColumns(newColumns)
 }
 
-func (p *ProcessList) getColumnIndexForSortKey(sortKey viewmodel.SortKey) int {
-	switch sortKey {
-	case viewmodel.SortByPID:
-		return 0
-	case viewmodel.SortByProgramName:
-		return 1
-	case viewmodel.SortByUser:
-		return 2
-	case viewmodel.SortByCPU:
-		return 4
-	case viewmodel.SortByMEM:
-		return 5
+// stateAlert summarizes processes in states that usually need attention
+func (p *ProcessList) stateAlert() string {
+	var zombies, uninterruptible int
+	for _, proc := range p.stateManager.getProcs() {
+		switch proc.State {
+		case taskmanager.StateZombie:
+			zombies++
+		case taskmanager.StateUninterruptible:
+			uninterruptible++
+		}
+	}
+	var parts []string
+	if zombies > 0 {
+		parts = append(parts, fmt.Sprintf("%d zombie", zombies))
+	}
+	if uninterruptible > 0 {
+		parts = append(parts, fmt.Sprintf("%d in D state", uninterruptible))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "⚠ " + strings.Join(parts, ", ")
+}
+
+// sortRankMarks number the sort keys in the column headers
+var sortRankMarks = []string{"¹", "²", "³"}
+
+func sortArrow(order viewmodel.SortOrder) string {
+	switch order {
+	case viewmodel.OrderASC:
+		return " ↑"
+	case viewmodel.OrderDESC:
+		return " ↓"
 	default:
-		return -1
+		return ""
+	}
+}
+
+// markPrefix flags marked rows in their first cell, as rows cannot be styled individually
+const markPrefix = "● "
+
+func buildRows(listRows []listRow, columns []Column, hist *history.Store, marks map[int]bool, expanded map[string]bool) []table.Row {
+	rows := make([]table.Row, len(listRows))
+	for i, lr := range listRows {
+		row := make(table.Row, len(columns))
+		for j, col := range columns {
+			row[j] = col.Value(lr.proc, hist)
+		}
+		if len(row) == 0 {
+			rows[i] = row
+			continue
+		}
+
+		label := labelColumn(columns)
+		marked := marks[lr.proc.PID]
+		switch {
+		case lr.group != nil:
+			row[label] = groupLabel(lr.group, expanded[lr.group.Key])
+			marked = len(lr.group.Members) > 0
+			for _, member := range lr.group.Members {
+				marked = marked && marks[member.PID]
+			}
+		case lr.member:
+			row[label] = memberIndent + row[label]
+		}
+		if marked {
+			row[0] = markPrefix + row[0]
+		}
+		rows[i] = row
 	}
+	return rows
 }
+
+type rowsChangedMsg struct{}
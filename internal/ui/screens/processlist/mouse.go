@@ -0,0 +1,59 @@
+package processlist
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/paulvinueza30/hyprtask/internal/ui/mouse"
+)
+
+// tableLayout is where the table was last drawn, relative to the screen
+type tableLayout struct {
+	top          int // first line of the column headers
+	left         int
+	headerHeight int // header text plus its border
+}
+
+func (p *ProcessList) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if delta := mouse.WheelDelta(msg); delta != 0 {
+		p.stateManager.moveCursor(delta * mouse.WheelStep)
+		return nil
+	}
+	if !mouse.IsLeftClick(msg) {
+		return nil
+	}
+
+	line := msg.Y - p.layout.top
+	switch {
+	case line < 0:
+		return nil
+	case line < p.layout.headerHeight:
+		if msg.Shift {
+			return p.stateManager.thenByColumn(p.columnAt(msg.X))
+		}
+		return p.stateManager.sortByColumn(p.columnAt(msg.X))
+	default:
+		row := p.stateManager.rowAt(line - p.layout.headerHeight)
+		if row < 0 {
+			return nil
+		}
+		p.stateManager.selectRow(row)
+		if p.clicks.Click(row) {
+			return p.stateManager.showDetails()
+		}
+	}
+	return nil
+}
+
+// columnAt returns the index of the visible column under x, or -1
+func (p *ProcessList) columnAt(x int) int {
+	edge := p.layout.left
+	if x < edge {
+		return -1
+	}
+	for i, col := range p.table.Columns() {
+		edge += col.Width + cellPadding
+		if x < edge {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,162 @@
+package processlist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
+)
+
+const (
+	sliderWidth       = 20
+	defaultIOLevel    = 4 // the kernel's default best-effort level
+	priorityLabelSize = 7
+)
+
+type priorityControl int
+
+const (
+	controlNice priorityControl = iota
+	controlIOClass
+	controlIOLevel
+	controlScope
+)
+
+func (c priorityControl) label() string {
+	switch c {
+	case controlNice:
+		return "Nice"
+	case controlIOClass:
+		return "Class"
+	case controlIOLevel:
+		return "Level"
+	default:
+		return "Apply"
+	}
+}
+
+// ioClassChoices are offered in this order, the unprivileged ones first
+var ioClassChoices = []taskmanager.IOClass{
+	taskmanager.IOClassBestEffort,
+	taskmanager.IOClassIdle,
+	taskmanager.IOClassRealtime,
+}
+
+// priorityForm holds the sliders of the renice and I/O priority dialogs
+type priorityForm struct {
+	controls []priorityControl
+	focus    int
+	nice     int
+	ioClass  int // index into ioClassChoices
+	ioLevel  int
+	scope    scopePicker
+}
+
+func newPriorityForm(request ShowConfirmationMsg) priorityForm {
+	form := priorityForm{nice: request.Nice, ioLevel: defaultIOLevel, scope: newScopePicker(request)}
+	if request.Type == taskmanager.TaskActionIOPriority {
+		form.controls = []priorityControl{controlIOClass, controlIOLevel, controlScope}
+	} else {
+		form.controls = []priorityControl{controlNice, controlScope}
+	}
+	return form
+}
+
+// handleKey moves between and adjusts the controls, reporting whether it used the key
+func (f *priorityForm) handleKey(key string) bool {
+	switch key {
+	case "up", "k", "shift+tab":
+		f.focus = max(f.focus-1, 0)
+	case "down", "j", "tab":
+		f.focus = min(f.focus+1, len(f.controls)-1)
+	case "left", "h", "-":
+		f.adjust(-1)
+	case "right", "l", "+":
+		f.adjust(1)
+	default:
+		return false
+	}
+	return true
+}
+
+func (f *priorityForm) adjust(delta int) {
+	switch f.controls[f.focus] {
+	case controlNice:
+		f.nice = clamp(f.nice+delta, taskmanager.MinNice, taskmanager.MaxNice)
+	case controlIOClass:
+		f.ioClass = clamp(f.ioClass+delta, 0, len(ioClassChoices)-1)
+	case controlIOLevel:
+		f.ioLevel = clamp(f.ioLevel+delta, 0, taskmanager.MaxIOLevel)
+	case controlScope:
+		f.scope.move(delta)
+	}
+}
+
+// apply fills in the chosen values and processes
+func (f *priorityForm) apply(action *messages.ProcessActionMsg) {
+	f.scope.apply(action)
+	action.Nice = f.nice
+	action.IOClass = ioClassChoices[f.ioClass]
+	action.IOLevel = f.ioLevel
+}
+
+func (f *priorityForm) view() string {
+	t := theme.Get().Dialog
+	lines := make([]string, len(f.controls))
+	for i, control := range f.controls {
+		label := fmt.Sprintf("%-*s", priorityLabelSize, control.label())
+		if i == f.focus {
+			label = t.Cursor.Render("› " + label)
+		} else {
+			label = "  " + label
+		}
+		lines[i] = label + " " + f.controlValue(control)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (f *priorityForm) controlValue(control priorityControl) string {
+	switch control {
+	case controlNice:
+		return fmt.Sprintf("%d %s %d   %d", taskmanager.MinNice,
+			slider(f.nice, taskmanager.MinNice, taskmanager.MaxNice), taskmanager.MaxNice, f.nice)
+	case controlIOClass:
+		return "◀ " + ioClassChoices[f.ioClass].String() + " ▶"
+	case controlIOLevel:
+		if ioClassChoices[f.ioClass] == taskmanager.IOClassIdle {
+			return "idle has no levels"
+		}
+		return fmt.Sprintf("0 %s %d   %d", slider(f.ioLevel, 0, taskmanager.MaxIOLevel), taskmanager.MaxIOLevel, f.ioLevel)
+	default:
+		return f.scope.view()
+	}
+}
+
+// hint explains the direction of the focused slider
+func (f *priorityForm) hint() string {
+	switch f.controls[f.focus] {
+	case controlNice:
+		return "lower nice runs first; below the current value needs root"
+	case controlIOClass:
+		if ioClassChoices[f.ioClass] == taskmanager.IOClassRealtime {
+			return "realtime needs root"
+		}
+		return "idle only gets disk time nobody else wants"
+	case controlIOLevel:
+		return "level 0 is served first"
+	default:
+		return scopeHint
+	}
+}
+
+// slider draws value on a track from lo to hi
+func slider(value, lo, hi int) string {
+	pos := (value - lo) * (sliderWidth - 1) / (hi - lo)
+	return strings.Repeat("━", pos) + "●" + strings.Repeat("─", sliderWidth-1-pos)
+}
+
+func clamp(value, lo, hi int) int {
+	return min(max(value, lo), hi)
+}
@@ -0,0 +1,73 @@
+package processlist
+
+import (
+	"fmt"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+)
+
+// scopeHint explains the tree scopes
+const scopeHint = "children are looked up when the action runs"
+
+// scopeChoice is one answer to "which processes does this apply to"
+type scopeChoice struct {
+	label   string
+	scope   taskmanager.Scope
+	targets []Target
+}
+
+// scopePicker offers the targets alone, with their children, the whole
+// workspace of the first target's window, or for affinity a single thread
+type scopePicker struct {
+	choices []scopeChoice
+	index   int
+}
+
+func newScopePicker(request ShowConfirmationMsg) scopePicker {
+	selected := "selected process"
+	if len(request.Targets) > 1 {
+		selected = fmt.Sprintf("%d selected processes", len(request.Targets))
+	}
+	choices := []scopeChoice{
+		{label: selected, scope: taskmanager.ScopeProcess, targets: request.Targets},
+		{label: selected + " and children", scope: taskmanager.ScopeTree, targets: request.Targets},
+	}
+	if ws := request.Workspace; ws != nil && len(ws.Targets) > 0 {
+		choices = append(choices, scopeChoice{
+			label:   fmt.Sprintf("workspace %s (%d windows) and children", ws.Name, len(ws.Targets)),
+			scope:   taskmanager.ScopeTree,
+			targets: ws.Targets,
+		})
+	}
+	for _, thread := range request.Threads {
+		choices = append(choices, scopeChoice{
+			label:   fmt.Sprintf("thread %d (%s) only", thread.PID, thread.Name),
+			scope:   taskmanager.ScopeThread,
+			targets: []Target{thread},
+		})
+	}
+	return scopePicker{choices: choices}
+}
+
+func (s *scopePicker) move(delta int) {
+	s.index = clamp(s.index+delta, 0, len(s.choices)-1)
+}
+
+func (s *scopePicker) targets() []Target {
+	return s.choices[s.index].targets
+}
+
+// apply sets the PIDs and scope of action to the chosen ones
+func (s *scopePicker) apply(action *messages.ProcessActionMsg) {
+	choice := s.choices[s.index]
+	action.PIDs = make([]int, len(choice.targets))
+	for i, target := range choice.targets {
+		action.PIDs[i] = target.PID
+	}
+	action.Scope = choice.scope
+}
+
+func (s *scopePicker) view() string {
+	return "◀ " + s.choices[s.index].label + " ▶"
+}
@@ -1,8 +1,13 @@
 package processlist
 
 import (
+	"slices"
+	"strings"
+	"syscall"
+
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/paulvinueza30/hyprtask/internal/history"
 	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
 	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
 	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
@@ -12,56 +17,134 @@ import (
 type sortOptions struct {
 	key viewmodel.SortKey
 	order viewmodel.SortOrder
+	thenBy []viewmodel.SortOption // tie-breakers, in priority order
 }
 
 type state struct {
 	workspaceID   *int
 	workspaceName *string
 	processList   []taskmanager.TaskProcess
+	history       *history.Store
 	sortOptions   sortOptions
+	columns       []Column // visible columns, in display order
+	marks         map[int]bool // PIDs marked for a batch action
+	cpus          int          // number of CPUs, 0 until the first sample
+	groupBy       viewmodel.GroupBy
+	groups        []viewmodel.ProcessGroup
+	expanded      map[string]bool // keys of the groups showing their members
+	rows          []listRow       // what the table shows, in order
+	cursor        int             // selected row
+	offset        int             // first row on screen; the table is only given the rows on screen
 }
 type stateManager struct {
 	state *state
 	table *table.Model
 }
 
-func newStateManager(procs []taskmanager.TaskProcess, table *table.Model) *stateManager {
+func newStateManager(procs []taskmanager.TaskProcess, columnIDs []string, sort viewmodel.ViewOptions, table *table.Model) *stateManager {
 	sortOptions := sortOptions{
-		key: viewmodel.SortByNone,
-		order: viewmodel.OrderNone,
+		key: sort.SortKey,
+		order: sort.SortOrder,
+		thenBy: sort.ThenBy,
 	}
-	return &stateManager{
+	sm := &stateManager{
 		state: &state{
 			workspaceID:   nil,
 			workspaceName: nil,
 			processList:   procs,
 			sortOptions:   sortOptions,
+			columns:       resolveColumns(columnIDs),
+			marks:         make(map[int]bool),
+			expanded:      make(map[string]bool),
 		},
 		table: table,
 	}
+	sm.buildListRows()
+	return sm
 }
 
 func (sm *stateManager) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
-	action, handled := keymap.Get().HandleKeyMsg(msg)
+	action, handled := keymap.Get().HandleKeyMsg(screens.ProcessList, msg)
 	if !handled {
 		return nil
 	}
+	if keymap.IsReplayAction(action) {
+		return func() tea.Msg {
+			return messages.ReplayControlMsg{Action: action}
+		}
+	}
 
 	switch action {
-	case "change_to_workspace_view":
+	case keymap.ActionChangeToWorkspaceView:
 		return sm.changeToWorkspaceSelectorView()
-	case "quit":
+	case keymap.ActionQuit:
 		return tea.Quit
-	case "sort_key_left":
+	case keymap.ActionToggleHelp:
+		return func() tea.Msg {
+			return messages.ShowHelpMsg{}
+		}
+	case keymap.ActionSortKeyLeft:
 		return sm.sortKeyLeft()
-	case "sort_key_right":
+	case keymap.ActionSortKeyRight:
 		return sm.sortKeyRight()
-	case "toggle_sort_order":
+	case keymap.ActionToggleSortOrder:
 		return sm.toggleSortOrder()
-	case "kill_process":
+	case keymap.ActionPushSortKey:
+		return sm.pushSortKey()
+	case keymap.ActionClearTieBreakers:
+		return sm.clearTieBreakers()
+	case keymap.ActionKillProcess:
 		return sm.killProcess(false)
-	case "kill_process_force":
+	case keymap.ActionKillProcessForce:
 		return sm.killProcess(true)
+	case keymap.ActionOpenGraph:
+		return sm.openProcessGraph()
+	case keymap.ActionOpenSystemGraph:
+		return openSystemGraph()
+	case keymap.ActionOpenColumnPicker:
+		return sm.openColumnPicker()
+	case keymap.ActionShowDetails:
+		return sm.showDetails()
+	case keymap.ActionToggleMark:
+		return sm.toggleMark()
+	case keymap.ActionMarkAll:
+		return sm.markAll()
+	case keymap.ActionMarkByFilter:
+		return func() tea.Msg {
+			return ShowFilterPromptMsg{}
+		}
+	case keymap.ActionClearMarks:
+		return sm.clearMarks()
+	case keymap.ActionSendSignal:
+		return sm.confirmBatch(ShowConfirmationMsg{Type: taskmanager.TaskActionSignal, Signal: syscall.SIGTERM, ChooseSignal: true})
+	case keymap.ActionSuspendProcess:
+		return sm.suspendOrResume()
+	case keymap.ActionReniceProcess:
+		return sm.renice()
+	case keymap.ActionSetIOPriority:
+		return sm.setIOPriority()
+	case keymap.ActionCycleGrouping:
+		return sm.cycleGrouping()
+	case keymap.ActionSetAffinity:
+		return sm.setAffinity()
+	case keymap.ActionCloseWindow:
+		return sm.closeWindows()
+	case keymap.ActionNavigateUp:
+		sm.moveCursor(-1)
+	case keymap.ActionNavigateDown:
+		sm.moveCursor(1)
+	case keymap.ActionScrollUp:
+		sm.moveCursor(-sm.pageSize())
+	case keymap.ActionScrollDown:
+		sm.moveCursor(sm.pageSize())
+	case keymap.ActionHalfPageUp:
+		sm.moveCursor(-max(sm.pageSize()/2, 1))
+	case keymap.ActionHalfPageDown:
+		sm.moveCursor(max(sm.pageSize()/2, 1))
+	case keymap.ActionGoToTop:
+		sm.selectRow(0)
+	case keymap.ActionGoToBottom:
+		sm.selectRow(len(sm.state.rows) - 1)
 	}
 
 	return nil
@@ -75,16 +158,55 @@ func (sm *stateManager) changeToWorkspaceSelectorView() tea.Cmd {
 
 func (sm *stateManager) setState(msg messages.ProcessListMsg) {
 	currentSortOptions := sm.state.sortOptions
+	previous := sm.state
 	sm.state = &state{
 		workspaceID:   msg.WorkspaceID,
 		workspaceName: msg.WorkspaceName,
 		processList:   msg.Processes,
+		history:       msg.History,
 		sortOptions:   currentSortOptions,
+		columns:       sm.state.columns,
+		marks:         make(map[int]bool),
+		cpus:          msg.CPUs,
+		groupBy:       msg.GroupBy,
+		groups:        msg.Groups,
+		expanded:      previous.expanded,
+	}
+	// Marks of processes that exited or are no longer listed are dropped
+	for _, proc := range msg.Processes {
+		if previous.marks[proc.PID] {
+			sm.state.marks[proc.PID] = true
+		}
 	}
+	if msg.GroupBy != previous.groupBy {
+		sm.state.expanded = make(map[string]bool)
+	}
+	sm.buildListRows()
+	// A refresh keeps the selection on the same process or group and the view
+	// scrolled in place; only another workspace or grouping starts at the top
+	if msg.GroupBy == previous.groupBy && sameWorkspace(msg.WorkspaceID, previous.workspaceID) {
+		sm.state.cursor = max(min(previous.cursor, len(sm.state.rows)-1), 0)
+		sm.state.offset = previous.offset
+		if selected, ok := previous.selectedRow(); ok {
+			if i := slices.IndexFunc(sm.state.rows, selected.sameAs); i != -1 {
+				sm.state.cursor = i
+			}
+		}
+	}
+}
+
+func sameWorkspace(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 func (sm *stateManager) getProcs() []taskmanager.TaskProcess {
 	return sm.state.processList
 }
+func (sm *stateManager) getHistory() *history.Store {
+	return sm.state.history
+}
 func (sm *stateManager) getWorkspaceID() *int {
 	return sm.state.workspaceID
 }
@@ -92,12 +214,38 @@ func (sm *stateManager) getWorkspaceName() *string {
 	return sm.state.workspaceName
 }
 
-var visualSortOrder = []viewmodel.SortKey{
-	viewmodel.SortByPID,
-	viewmodel.SortByProgramName,
-	viewmodel.SortByUser,
-	viewmodel.SortByCPU,
-	viewmodel.SortByMEM,
+func (sm *stateManager) visibleColumns() []Column {
+	return sm.state.columns
+}
+
+func (sm *stateManager) setColumns(ids []string) {
+	sm.state.columns = resolveColumns(ids)
+}
+
+func (sm *stateManager) visibleColumnIDs() []string {
+	ids := make([]string, len(sm.state.columns))
+	for i, col := range sm.state.columns {
+		ids[i] = col.ID
+	}
+	return ids
+}
+
+// visualSortOrder returns the sort keys of the visible columns, left to right
+func (sm *stateManager) visualSortOrder() []viewmodel.SortKey {
+	var keys []viewmodel.SortKey
+	for _, col := range sm.visibleColumns() {
+		if col.SortKey != viewmodel.SortByNone {
+			keys = append(keys, col.SortKey)
+		}
+	}
+	return keys
+}
+
+func (sm *stateManager) openColumnPicker() tea.Cmd {
+	visible := sm.visibleColumnIDs()
+	return func() tea.Msg {
+		return ShowColumnPickerMsg{Visible: visible}
+	}
 }
 
 func (sm *stateManager) getCurrentVisualIndex() int {
@@ -105,7 +253,7 @@ func (sm *stateManager) getCurrentVisualIndex() int {
 	if currentKey == viewmodel.SortByNone {
 		return -1
 	}
-	for i, key := range visualSortOrder {
+	for i, key := range sm.visualSortOrder() {
 		if key == currentKey {
 			return i
 		}
@@ -114,6 +262,10 @@ func (sm *stateManager) getCurrentVisualIndex() int {
 }
 
 func (sm *stateManager) sortKeyLeft() tea.Cmd {
+	visualSortOrder := sm.visualSortOrder()
+	if len(visualSortOrder) == 0 {
+		return nil
+	}
 	currentIndex := sm.getCurrentVisualIndex()
 	if currentIndex == -1 {
 		sm.state.sortOptions.key = visualSortOrder[len(visualSortOrder)-1]
@@ -122,21 +274,21 @@ func (sm *stateManager) sortKeyLeft() tea.Cmd {
 	} else {
 		sm.state.sortOptions.key = viewmodel.SortByNone
 		sm.state.sortOptions.order = viewmodel.OrderNone
-		return func() tea.Msg {
-			return messages.NewChangeSortOptionMsg(sm.state.sortOptions.key, sm.state.sortOptions.order)
-		}
+		return sm.sortChanged()
 	}
 	
 	if sm.state.sortOptions.order == viewmodel.OrderNone {
 		sm.state.sortOptions.order = viewmodel.OrderDESC
 	}
 	
-	return func() tea.Msg {
-		return messages.NewChangeSortOptionMsg(sm.state.sortOptions.key, sm.state.sortOptions.order)
-	}
+	return sm.sortChanged()
 }
 
 func (sm *stateManager) sortKeyRight() tea.Cmd {
+	visualSortOrder := sm.visualSortOrder()
+	if len(visualSortOrder) == 0 {
+		return nil
+	}
 	currentIndex := sm.getCurrentVisualIndex()
 	if currentIndex == -1 {
 		sm.state.sortOptions.key = visualSortOrder[0]
@@ -145,18 +297,14 @@ func (sm *stateManager) sortKeyRight() tea.Cmd {
 	} else {
 		sm.state.sortOptions.key = viewmodel.SortByNone
 		sm.state.sortOptions.order = viewmodel.OrderNone
-		return func() tea.Msg {
-			return messages.NewChangeSortOptionMsg(sm.state.sortOptions.key, sm.state.sortOptions.order)
-		}
+		return sm.sortChanged()
 	}
 	
 	if sm.state.sortOptions.order == viewmodel.OrderNone {
 		sm.state.sortOptions.order = viewmodel.OrderDESC
 	}
 	
-	return func() tea.Msg {
-		return messages.NewChangeSortOptionMsg(sm.state.sortOptions.key, sm.state.sortOptions.order)
-	}
+	return sm.sortChanged()
 }
 func (sm *stateManager) toggleSortOrder() tea.Cmd {
 	switch sm.state.sortOptions.order {
@@ -165,30 +313,423 @@ func (sm *stateManager) toggleSortOrder() tea.Cmd {
 	case viewmodel.OrderDESC:
 		sm.state.sortOptions.order = viewmodel.OrderASC
 	}
+	return sm.sortChanged()
+}
+
+// sortByColumn sorts by the column at index, flipping the order when it
+// already is the sort key
+func (sm *stateManager) sortByColumn(index int) tea.Cmd {
+	columns := sm.visibleColumns()
+	if index < 0 || index >= len(columns) || columns[index].SortKey == viewmodel.SortByNone {
+		return nil
+	}
+	if columns[index].SortKey == sm.state.sortOptions.key {
+		return sm.toggleSortOrder()
+	}
+
+	sm.state.sortOptions.key = columns[index].SortKey
+	if sm.state.sortOptions.order == viewmodel.OrderNone {
+		sm.state.sortOptions.order = viewmodel.OrderDESC
+	}
+	return sm.sortChanged()
+}
+
+// thenByColumn adds the column at index as the last tie-breaker, or flips its
+// order when it already sorts
+func (sm *stateManager) thenByColumn(index int) tea.Cmd {
+	columns := sm.visibleColumns()
+	if index < 0 || index >= len(columns) || columns[index].SortKey == viewmodel.SortByNone {
+		return nil
+	}
+	key := columns[index].SortKey
+	opts := &sm.state.sortOptions
+	if opts.key == viewmodel.SortByNone || key == opts.key {
+		return sm.sortByColumn(index)
+	}
+	for i := range opts.thenBy {
+		if opts.thenBy[i].Key == key {
+			opts.thenBy[i].Order = flipOrder(opts.thenBy[i].Order)
+			return sm.sortChanged()
+		}
+	}
+	if len(opts.thenBy) == viewmodel.MaxThenBy {
+		opts.thenBy = opts.thenBy[:viewmodel.MaxThenBy-1]
+	}
+	opts.thenBy = append(opts.thenBy, viewmodel.SortOption{Key: key, Order: viewmodel.OrderDESC})
+	return sm.sortChanged()
+}
+
+// pushSortKey keeps the current sort key as the first tie-breaker, so the
+// next key chosen sorts first
+func (sm *stateManager) pushSortKey() tea.Cmd {
+	opts := &sm.state.sortOptions
+	if opts.key == viewmodel.SortByNone {
+		return nil
+	}
+	pushed := viewmodel.SortOption{Key: opts.key, Order: opts.order}
+	opts.thenBy = append([]viewmodel.SortOption{pushed}, opts.thenBy...)
+	opts.key, opts.order = viewmodel.SortByNone, viewmodel.OrderNone
+	return sm.sortChanged()
+}
+
+func (sm *stateManager) clearTieBreakers() tea.Cmd {
+	if len(sm.state.sortOptions.thenBy) == 0 {
+		return nil
+	}
+	sm.state.sortOptions.thenBy = nil
+	return sm.sortChanged()
+}
+
+// sortChanged drops tie-breakers that repeat a higher key and sends the
+// sort options to the view model
+func (sm *stateManager) sortChanged() tea.Cmd {
+	opts := &sm.state.sortOptions
+	seen := map[viewmodel.SortKey]bool{opts.key: true}
+	var thenBy []viewmodel.SortOption
+	for _, option := range opts.thenBy {
+		if !seen[option.Key] && len(thenBy) < viewmodel.MaxThenBy {
+			seen[option.Key] = true
+			thenBy = append(thenBy, option)
+		}
+	}
+	opts.thenBy = thenBy
+
+	key, order := opts.key, opts.order
+	thenBy = slices.Clone(thenBy)
 	return func() tea.Msg {
-		return messages.NewChangeSortOptionMsg(sm.state.sortOptions.key, sm.state.sortOptions.order)
+		return messages.NewChangeSortOptionMsg(key, order, thenBy)
+	}
+}
+
+// sortRank returns the order and 1-based priority of key among the sort keys,
+// or 0 when it does not sort
+func (sm *stateManager) sortRank(key viewmodel.SortKey) (viewmodel.SortOrder, int) {
+	opts := sm.state.sortOptions
+	if key == viewmodel.SortByNone {
+		return viewmodel.OrderNone, 0
+	}
+	if key == opts.key {
+		return opts.order, 1
+	}
+	for i, option := range opts.thenBy {
+		if option.Key == key {
+			return option.Order, i + 2
+		}
+	}
+	return viewmodel.OrderNone, 0
+}
+
+func flipOrder(order viewmodel.SortOrder) viewmodel.SortOrder {
+	if order == viewmodel.OrderASC {
+		return viewmodel.OrderDESC
+	}
+	return viewmodel.OrderASC
+}
+
+func (sm *stateManager) moveCursor(delta int) {
+	sm.selectRow(sm.state.cursor + delta)
+}
+
+// selectRow moves the cursor to row, scrolling as little as needed to keep it
+// on screen
+func (sm *stateManager) selectRow(row int) {
+	sm.state.cursor = row
+	sm.syncTable()
+}
+
+// syncTable clamps the cursor to the rows, scrolls it on screen and hands the
+// rows on screen to the table. The table never scrolls by itself, so row i of
+// the table is row offset+i of the list.
+func (sm *stateManager) syncTable() {
+	if sm.table == nil {
+		return
+	}
+	st := sm.state
+	height := sm.pageSize()
+	st.cursor = max(min(st.cursor, len(st.rows)-1), 0)
+	st.offset = min(st.offset, st.cursor)
+	st.offset = max(st.offset, st.cursor-height+1)
+	st.offset = max(min(st.offset, len(st.rows)-height), 0)
+
+	end := min(st.offset+height, len(st.rows))
+	sm.table.SetRows(buildRows(st.rows[st.offset:end], st.columns, st.history, st.marks, st.expanded))
+	sm.table.SetCursor(st.cursor - st.offset)
+}
+
+// rowAt returns the index of the row on line y of the table body, or -1
+func (sm *stateManager) rowAt(y int) int {
+	row := sm.state.offset + y
+	if y < 0 || y >= sm.pageSize() || row >= len(sm.state.rows) {
+		return -1
+	}
+	return row
+}
+
+func (sm *stateManager) pageSize() int {
+	if sm.table == nil {
+		return 1
 	}
+	return max(sm.table.Height(), 1)
 }
 
 func (sm *stateManager) updateTable(table *table.Model) {
 	sm.table = table
 }
 
+func (sm *stateManager) getMarks() map[int]bool {
+	return sm.state.marks
+}
+
+// selectedProcess returns the process under the cursor, or the aggregate of the group under it
+func (sm *stateManager) selectedProcess() (taskmanager.TaskProcess, bool) {
+	row, ok := sm.selectedRow()
+	return row.proc, ok
+}
+
+// targets returns the marked processes, or the selected one when none are
+// marked. A selected group stands for all of its members.
+func (sm *stateManager) targets() []taskmanager.TaskProcess {
+	if len(sm.state.marks) == 0 {
+		row, ok := sm.selectedRow()
+		switch {
+		case !ok:
+			return nil
+		case row.group != nil:
+			return row.group.Members
+		default:
+			return []taskmanager.TaskProcess{row.proc}
+		}
+	}
+	var procs []taskmanager.TaskProcess
+	for _, proc := range sm.state.processList {
+		if sm.state.marks[proc.PID] {
+			procs = append(procs, proc)
+		}
+	}
+	return procs
+}
+
+// rowsChanged asks for the table to be rebuilt, e.g. after marking or expanding a group
+func rowsChanged() tea.Msg {
+	return rowsChangedMsg{}
+}
+
+// toggleMark marks or unmarks the selected process, or every member of the
+// selected group, and moves on to the next row
+func (sm *stateManager) toggleMark() tea.Cmd {
+	row, ok := sm.selectedRow()
+	if !ok {
+		return nil
+	}
+	procs := []taskmanager.TaskProcess{row.proc}
+	if row.group != nil {
+		procs = row.group.Members
+	}
+	mark := !sm.allMarked(procs)
+	for _, proc := range procs {
+		if mark {
+			sm.state.marks[proc.PID] = true
+		} else {
+			delete(sm.state.marks, proc.PID)
+		}
+	}
+	sm.moveCursor(1)
+	return rowsChanged
+}
+
+func (sm *stateManager) allMarked(procs []taskmanager.TaskProcess) bool {
+	for _, proc := range procs {
+		if !sm.state.marks[proc.PID] {
+			return false
+		}
+	}
+	return len(procs) > 0
+}
+
+// markAll marks every listed process, or clears the marks when all are marked
+func (sm *stateManager) markAll() tea.Cmd {
+	if len(sm.state.marks) == len(sm.state.processList) {
+		return sm.clearMarks()
+	}
+	for _, proc := range sm.state.processList {
+		sm.state.marks[proc.PID] = true
+	}
+	return rowsChanged
+}
+
+// markMatching marks the processes whose program or command contains query, ignoring case
+func (sm *stateManager) markMatching(query string) {
+	query = strings.ToLower(query)
+	for _, proc := range sm.state.processList {
+		if strings.Contains(strings.ToLower(proc.ProgramName), query) ||
+			strings.Contains(strings.ToLower(proc.CommandLine), query) {
+			sm.state.marks[proc.PID] = true
+		}
+	}
+}
+
+func (sm *stateManager) clearMarks() tea.Cmd {
+	clear(sm.state.marks)
+	return rowsChanged
+}
+
+// confirmBatch asks for confirmation before request is applied to the targets
+func (sm *stateManager) confirmBatch(request ShowConfirmationMsg) tea.Cmd {
+	return confirm(request, sm.targets())
+}
+
+func confirm(request ShowConfirmationMsg, procs []taskmanager.TaskProcess) tea.Cmd {
+	if len(procs) == 0 {
+		return nil
+	}
+	for _, proc := range procs {
+		request.Targets = append(request.Targets, Target{PID: proc.PID, Name: proc.ProgramName})
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
 func (sm *stateManager) killProcess(force bool) tea.Cmd {
-	if sm.table == nil {
+	signal := syscall.SIGTERM
+	if force {
+		signal = syscall.SIGKILL
+	}
+	return sm.confirmBatch(ShowConfirmationMsg{Type: taskmanager.TaskActionSignal, Signal: signal})
+}
+
+// suspendOrResume stops the targets, or continues them when all are already stopped
+func (sm *stateManager) suspendOrResume() tea.Cmd {
+	procs := sm.targets()
+	signal := syscall.SIGCONT
+	for _, proc := range procs {
+		if proc.State != taskmanager.StateStopped {
+			signal = syscall.SIGSTOP
+			break
+		}
+	}
+	return confirm(ShowConfirmationMsg{Type: taskmanager.TaskActionSignal, Signal: signal}, procs)
+}
+
+func (sm *stateManager) renice() tea.Cmd {
+	procs := sm.targets()
+	if len(procs) == 0 {
 		return nil
 	}
-	selectedRow := sm.table.Cursor()
-	if selectedRow >= 0 && selectedRow < len(sm.state.processList) {
-		proc := sm.state.processList[selectedRow]
-		return func() tea.Msg {
-			return ShowConfirmationMsg{
-				PID:         proc.PID,
-				ProcessName: proc.ProgramName,
-				Command:     proc.CommandLine,
-				Force:       force,
-			}
+	return confirm(ShowConfirmationMsg{
+		Type:      taskmanager.TaskActionRenice,
+		Nice:      procs[0].Nice,
+		Workspace: sm.workspaceOf(procs[0]),
+	}, procs)
+}
+
+func (sm *stateManager) setIOPriority() tea.Cmd {
+	procs := sm.targets()
+	if len(procs) == 0 {
+		return nil
+	}
+	return confirm(ShowConfirmationMsg{
+		Type:      taskmanager.TaskActionIOPriority,
+		Workspace: sm.workspaceOf(procs[0]),
+	}, procs)
+}
+
+func (sm *stateManager) setAffinity() tea.Cmd {
+	procs := sm.targets()
+	if len(procs) == 0 {
+		return nil
+	}
+	return confirm(ShowConfirmationMsg{
+		Type:      taskmanager.TaskActionAffinity,
+		CPUs:      sm.state.cpus,
+		Affinity:  procs[0].Affinity,
+		Workspace: sm.workspaceOf(procs[0]),
+		Threads:   threadTargets(procs),
+	}, procs)
+}
+
+// threadTargets lists the threads of a single multithreaded process, which
+// can be pinned one by one
+func threadTargets(procs []taskmanager.TaskProcess) []Target {
+	if len(procs) != 1 || procs[0].Threads < 2 {
+		return nil
+	}
+	threads, err := taskmanager.Threads(procs[0].PID)
+	if err != nil || len(threads) < 2 {
+		return nil
+	}
+	targets := make([]Target, len(threads))
+	for i, thread := range threads {
+		targets[i] = Target{PID: thread.TID, Name: thread.Name}
+	}
+	return targets
+}
+
+// pinnedCores returns the CPUs the selected process is restricted to, or nil
+// when it may use all of them
+func (sm *stateManager) pinnedCores() []int {
+	proc, ok := sm.selectedProcess()
+	if !ok || len(proc.Affinity) == 0 || len(proc.Affinity) >= sm.state.cpus {
+		return nil
+	}
+	return proc.Affinity
+}
+
+// workspaceOf lists the processes on the workspace of proc's window, or nil
+// when proc has none
+func (sm *stateManager) workspaceOf(proc taskmanager.TaskProcess) *WorkspaceTargets {
+	if proc.Meta == nil || proc.Meta.Hyprland == nil {
+		return nil
+	}
+	workspace := proc.Meta.Hyprland.Workspace
+	targets := &WorkspaceTargets{Name: workspace.Name}
+	for _, p := range sm.state.processList {
+		if p.Meta != nil && p.Meta.Hyprland != nil && p.Meta.Hyprland.Workspace.ID == workspace.ID {
+			targets.Targets = append(targets.Targets, Target{PID: p.PID, Name: p.ProgramName})
 		}
 	}
-	return nil
-}
\ No newline at end of file
+	return targets
+}
+
+// closeWindows closes the Hyprland windows of the targets that have one
+func (sm *stateManager) closeWindows() tea.Cmd {
+	var withWindow []taskmanager.TaskProcess
+	for _, proc := range sm.targets() {
+		if proc.Meta != nil && proc.Meta.Hyprland != nil {
+			withWindow = append(withWindow, proc)
+		}
+	}
+	return confirm(ShowConfirmationMsg{Type: taskmanager.TaskActionCloseWindow}, withWindow)
+}
+
+// showDetails opens the details of the selected process, or expands or
+// collapses the selected group
+func (sm *stateManager) showDetails() tea.Cmd {
+	if sm.toggleGroup() {
+		return rowsChanged
+	}
+	proc, ok := sm.selectedProcess()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		return ShowDetailsMsg{Process: proc, CPUs: sm.state.cpus}
+	}
+}
+
+func (sm *stateManager) openProcessGraph() tea.Cmd {
+	row, ok := sm.selectedRow()
+	if !ok || row.group != nil {
+		return nil
+	}
+	proc := row.proc
+	return func() tea.Msg {
+		return messages.NewChangeScreenMsg(screens.Graph, messages.NewGraphMsg(messages.GraphProcess, proc.PID, proc.ProgramName))
+	}
+}
+
+func openSystemGraph() tea.Cmd {
+	return func() tea.Msg {
+		return messages.NewChangeScreenMsg(screens.Graph, messages.NewGraphMsg(messages.GraphSystem, 0, ""))
+	}
+}
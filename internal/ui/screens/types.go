@@ -5,4 +5,18 @@ type ScreenType int
 const (
 	WorkspaceSelector ScreenType = iota
 	ProcessList
+	Graph
 )
+
+var screenNames = map[ScreenType]string{
+	WorkspaceSelector: "workspaces",
+	ProcessList:       "processes",
+	Graph:             "graph",
+}
+
+func (s ScreenType) String() string {
+	if name, ok := screenNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
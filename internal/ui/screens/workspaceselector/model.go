@@ -6,9 +6,11 @@ import (
 	"github.com/76creates/stickers/flexbox"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/paulvinueza30/hyprtask/internal/ui/components/hintbar"
 	"github.com/paulvinueza30/hyprtask/internal/ui/components/viewtitle"
 	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
 	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
+	"github.com/paulvinueza30/hyprtask/internal/ui/mouse"
 	"github.com/paulvinueza30/hyprtask/internal/ui/screens"
 	"github.com/paulvinueza30/hyprtask/internal/ui/theme"
 )
@@ -18,6 +20,8 @@ type WorkspaceSelectorView struct {
 	stateManager *stateManager
 
 	Title  tea.Model
+	clicks mouse.ClickTracker
+	layout gridLayout
 	width  int
 	height int
 }
@@ -51,10 +55,12 @@ func (ws *WorkspaceSelectorView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case messages.WorkspaceDataMsg:
-		ws.stateManager.createWorkspaceBoxes(msg.Workspaces)
+		ws.stateManager.createWorkspaceBoxes(msg.Workspaces, msg.History)
 	case tea.KeyMsg:
 		cmd := ws.stateManager.handleKeyMsg(msg)
 		return ws, cmd
+	case tea.MouseMsg:
+		return ws, ws.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		widthPadding := ws.calculateWidthPadding(msg.Width)
 		heightPadding := ws.calculateHeightPadding(msg.Height)
@@ -87,7 +93,7 @@ func (ws *WorkspaceSelectorView) View() string {
 	workspaceCountHeader := theme.Get().WorkspaceView.Title.Render(fmt.Sprintf("%d Workspaces", ws.stateManager.getWorkspaceCount()))
 	title := ws.Title.View()
 	workspaceGrid := ws.createWorkspaceGrid()
-	instructions := theme.Get().WorkspaceView.Details.Render(keymap.Get().GetHelpText(screens.WorkspaceSelector))
+	instructions := hintbar.Render(keymap.Get().ShortHelp(screens.WorkspaceSelector), ws.width)
 
 	centeredHeader := lipgloss.PlaceHorizontal(ws.width, lipgloss.Center, workspaceCountHeader)
 	var marginTop, marginBottom int
@@ -102,11 +108,15 @@ func (ws *WorkspaceSelectorView) View() string {
 	gridStyled := lipgloss.NewStyle().MarginTop(marginTop).MarginBottom(marginBottom).Render(workspaceGrid)
 	instructionsStyled := lipgloss.NewStyle().Render(instructions)
 
+	ws.layout.top = lipgloss.Height(headerStyled) + marginTop
+	ws.layout.left = (max(ws.width-ws.layout.width, 0) + 1) / 2
+
 	return lipgloss.JoinVertical(lipgloss.Center, headerStyled, gridStyled, instructionsStyled)
 }
 
 func (ws *WorkspaceSelectorView) createWorkspaceGrid() string {
 	workspaces := ws.stateManager.getWorkspaces()
+	ws.layout.rows = nil
 	if len(workspaces) == 0 {
 		return "No workspaces available"
 	}
@@ -127,15 +137,20 @@ func (ws *WorkspaceSelectorView) createWorkspaceGrid() string {
 	startIndex := scrollOffset * cols
 	row := ws.FlexBox.NewRow()
 	rowCount := 0
+	var tiles []tile
 
 	for i := startIndex; i < len(workspaces) && rowCount < maxVisibleRows; i++ {
 		workspace := workspaces[i]
-		cell := flexbox.NewCell(1, 1).SetContent(workspace.View())
+		content := workspace.View()
+		cell := flexbox.NewCell(1, 1).SetContent(content)
 		row.AddCells(cell)
+		tiles = append(tiles, tile{index: i, width: lipgloss.Width(content)})
 
 		workspaceIndexInRow := i - startIndex
 		if (workspaceIndexInRow+1)%cols == 0 || i == len(workspaces)-1 {
 			flexRows = append(flexRows, row)
+			ws.layout.rows = append(ws.layout.rows, tiles)
+			tiles = nil
 			rowCount++
 
 			if i < len(workspaces)-1 && rowCount < maxVisibleRows {
@@ -146,6 +161,8 @@ func (ws *WorkspaceSelectorView) createWorkspaceGrid() string {
 
 	ws.FlexBox.SetRows(flexRows)
 	gridContent := ws.FlexBox.Render()
+	ws.layout.width = lipgloss.Width(gridContent)
+	ws.layout.rowHeight = lipgloss.Height(gridContent) / len(flexRows)
 
 	// Add down arrow indicator if there are more workspaces below
 	if ws.hasMoreWorkspacesBelow() {
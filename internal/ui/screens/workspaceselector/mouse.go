@@ -0,0 +1,65 @@
+package workspaceselector
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/paulvinueza30/hyprtask/internal/ui/mouse"
+)
+
+// gridLayout is where the workspace tiles were last drawn, relative to the screen.
+// Tiles sit left aligned in their row, and every row gets the same height.
+type gridLayout struct {
+	top       int
+	left      int
+	width     int
+	rowHeight int
+	rows      [][]tile
+}
+
+type tile struct {
+	index int // position in the workspace list
+	width int
+}
+
+func (ws *WorkspaceSelectorView) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	switch mouse.WheelDelta(msg) {
+	case -1:
+		ws.stateManager.scrollUp()
+		return nil
+	case 1:
+		ws.stateManager.scrollDown()
+		return nil
+	}
+	if !mouse.IsLeftClick(msg) {
+		return nil
+	}
+
+	index, ok := ws.tileAt(msg.X, msg.Y)
+	if !ok {
+		return nil
+	}
+	ws.stateManager.selectIndex(index)
+	if ws.clicks.Click(index) {
+		return ws.stateManager.changeToWorkspaceProcsView()
+	}
+	return nil
+}
+
+// tileAt returns the index of the workspace drawn at x, y
+func (ws *WorkspaceSelectorView) tileAt(x, y int) (int, bool) {
+	layout := ws.layout
+	if layout.rowHeight == 0 || y < layout.top || x < layout.left {
+		return 0, false
+	}
+	row := (y - layout.top) / layout.rowHeight
+	if row >= len(layout.rows) {
+		return 0, false
+	}
+	edge := layout.left
+	for _, t := range layout.rows[row] {
+		edge += t.width
+		if x < edge {
+			return t.index, true
+		}
+	}
+	return 0, false
+}
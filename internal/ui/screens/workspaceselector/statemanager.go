@@ -2,6 +2,7 @@ package workspaceselector
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/paulvinueza30/hyprtask/internal/history"
 	"github.com/paulvinueza30/hyprtask/internal/ui/components/workspacebox"
 	"github.com/paulvinueza30/hyprtask/internal/ui/keymap"
 	"github.com/paulvinueza30/hyprtask/internal/ui/messages"
@@ -50,7 +51,7 @@ func newStateManager() *stateManager {
 	}
 }
 
-func (sm *stateManager) createWorkspaceBoxes(workspaceData []*viewmodel.WorkspaceData) {
+func (sm *stateManager) createWorkspaceBoxes(workspaceData []*viewmodel.WorkspaceData, hist *history.Store) {
 	sm.state.count = len(workspaceData)
 
 	// Track which workspaces exist in new data
@@ -77,6 +78,7 @@ func (sm *stateManager) createWorkspaceBoxes(workspaceData []*viewmodel.Workspac
 				workspaceBox.WindowCount = workspaceInfo.ActiveProcsCount
 				workspaceBox.CPUUsage = workspaceInfo.TotalCPU
 				workspaceBox.MemUsage = workspaceInfo.TotalMEM
+				workspaceBox.CPUHistory = workspaceCPUHistory(hist, workspaceID)
 			}
 		} else {
 			// Create new workspace box
@@ -87,6 +89,7 @@ func (sm *stateManager) createWorkspaceBoxes(workspaceData []*viewmodel.Workspac
 			workspaceBox.WindowCount = workspaceInfo.ActiveProcsCount
 			workspaceBox.CPUUsage = workspaceInfo.TotalCPU
 			workspaceBox.MemUsage = workspaceInfo.TotalMEM
+			workspaceBox.CPUHistory = workspaceCPUHistory(hist, workspaceID)
 
 			newWorkspaces = append(newWorkspaces, workspaceBox)
 		}
@@ -100,6 +103,13 @@ func (sm *stateManager) createWorkspaceBoxes(workspaceData []*viewmodel.Workspac
 	sm.updateWorkspaceSelection()
 }
 
+func workspaceCPUHistory(hist *history.Store, workspaceID int) []float64 {
+	if hist == nil {
+		return nil
+	}
+	return history.Values(hist.Workspace(workspaceID), history.CPU)
+}
+
 func (sm *stateManager) getWorkspaceCount() int {
 	return sm.state.count
 }
@@ -189,34 +199,53 @@ func (sm *stateManager) findNextColInRow(row, currentCol, direction int) int {
 }
 
 func (sm *stateManager) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
-	action, handled := keymap.Get().HandleKeyMsg(msg)
+	action, handled := keymap.Get().HandleKeyMsg(screens.WorkspaceSelector, msg)
 	if !handled {
 		return nil
 	}
+	if keymap.IsReplayAction(action) {
+		return func() tea.Msg {
+			return messages.ReplayControlMsg{Action: action}
+		}
+	}
 
 	switch action {
-	case "quit":
+	case keymap.ActionQuit:
 		return tea.Quit
-	case "navigate_left":
+	case keymap.ActionToggleHelp:
+		return func() tea.Msg {
+			return messages.ShowHelpMsg{}
+		}
+	case keymap.ActionNavigateLeft:
 		sm.handleLeft()
 		sm.updateWorkspaceSelection()
-	case "navigate_right":
+	case keymap.ActionNavigateRight:
 		sm.handleRight()
 		sm.updateWorkspaceSelection()
-	case "navigate_up":
+	case keymap.ActionNavigateUp:
 		sm.handleUp()
 		sm.updateWorkspaceSelection()
-	case "navigate_down":
+	case keymap.ActionNavigateDown:
 		sm.handleDown()
 		sm.updateWorkspaceSelection()
-	case "scroll_up":
+	case keymap.ActionScrollUp, keymap.ActionHalfPageUp:
 		sm.scrollUp()
-	case "scroll_down":
+	case keymap.ActionScrollDown, keymap.ActionHalfPageDown:
 		sm.scrollDown()
-	case "change_to_all_procs_view":
+	case keymap.ActionGoToTop:
+		sm.selectIndex(0)
+	case keymap.ActionGoToBottom:
+		sm.selectIndex(len(sm.state.workspaces) - 1)
+	case keymap.ActionChangeToAllProcsView:
 		return sm.changeToAllProcsView()
-	case "select_workspace":
+	case keymap.ActionSelectWorkspace:
 		return sm.changeToWorkspaceProcsView()
+	case keymap.ActionOpenGraph:
+		return sm.openWorkspaceGraph()
+	case keymap.ActionOpenSystemGraph:
+		return func() tea.Msg {
+			return messages.NewChangeScreenMsg(screens.Graph, messages.NewGraphMsg(messages.GraphSystem, 0, ""))
+		}
 	default:
 		return nil
 	}
@@ -265,6 +294,16 @@ func (sm *stateManager) handleDown() {
 	sm.ensureSelectionVisible()
 }
 
+// selectIndex selects the workspace at index, scrolling it into view
+func (sm *stateManager) selectIndex(index int) {
+	if index < 0 {
+		return
+	}
+	sm.state.selected = selected{row: index / 2, col: index % 2}
+	sm.ensureSelectionVisible()
+	sm.updateWorkspaceSelection()
+}
+
 func (sm *stateManager) getScrollOffset() int {
 	return sm.state.scrollOffset
 }
@@ -323,3 +362,19 @@ func (sm *stateManager) changeToWorkspaceProcsView() tea.Cmd {
 	}
 	return nil
 }
+
+func (sm *stateManager) openWorkspaceGraph() tea.Cmd {
+	selectedIndex := sm.getWorkspaceIndex(sm.state.selected)
+	if selectedIndex >= len(sm.state.workspaces) {
+		return nil
+	}
+
+	if workspaceBox, ok := sm.state.workspaces[selectedIndex].(*workspacebox.WorkspaceBox); ok {
+		wsID := workspaceBox.ID
+		wsName := workspaceBox.Name
+		return func() tea.Msg {
+			return messages.NewChangeScreenMsg(screens.Graph, messages.NewGraphMsg(messages.GraphWorkspace, wsID, wsName))
+		}
+	}
+	return nil
+}
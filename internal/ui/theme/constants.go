@@ -9,6 +9,8 @@ const (
 	DefaultColorAccent     = "#89B4FA" // Blue accent (common in TUI apps)
 	DefaultColorWarning    = "#F9E2AF" // Yellow warning
 	DefaultColorSuccess    = "#A6E3A1" // Green success
+	DefaultColorError      = "#F38BA8" // Red error
+	DefaultColorHighlight  = "#CBA6F7" // Mauve secondary accent
 )
 
 // Layout constants
@@ -0,0 +1,73 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Resolve finds the palette for a theme name. The name is a built-in theme,
+// "hyprland", a path to a theme file, or the name of a file in themesDir
+// without its .toml extension. An empty name picks the default theme, or
+// monochrome when NO_COLOR is set.
+func Resolve(name, themesDir string) (Palette, error) {
+	if name == "" {
+		if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+			return builtinPalettes[NameMonochrome], nil
+		}
+		return DefaultPalette(), nil
+	}
+	if palette, ok := builtinPalettes[name]; ok {
+		return palette, nil
+	}
+	if name == NameHyprland {
+		return HyprlandPalette(HyprlandConfigPath())
+	}
+	if strings.ContainsRune(name, filepath.Separator) || strings.HasSuffix(name, ".toml") {
+		return LoadFile(expandHome(name))
+	}
+
+	path := filepath.Join(themesDir, name+".toml")
+	palette, err := LoadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Palette{}, fmt.Errorf("unknown theme %q: not a built-in (%s) and %s does not exist",
+			name, strings.Join(Names(), ", "), path)
+	}
+	return palette, err
+}
+
+// LoadFile reads a theme file. It holds the palette colors as top-level keys,
+// e.g. accent = "#89B4FA"; colors left out come from the default theme.
+func LoadFile(path string) (Palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Palette{}, err
+	}
+	palette := DefaultPalette()
+	meta, err := toml.Decode(string(data), &palette)
+	if err != nil {
+		return Palette{}, fmt.Errorf("theme %s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = key.String()
+		}
+		return Palette{}, fmt.Errorf("theme %s: unknown keys: %s", path, strings.Join(keys, ", "))
+	}
+	return palette, nil
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
@@ -0,0 +1,222 @@
+package theme
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const maxSourceDepth = 8
+
+// hyprlandRoles lists, per palette color, the Hyprland variables that are
+// tried in order. The names follow the common theme ports (Catppuccin,
+// Gruvbox, pywal exports); the border settings are the fallback for accent and border.
+var hyprlandRoles = []struct {
+	target    func(*Palette) *string
+	variables []string
+}{
+	{func(p *Palette) *string { return &p.Background }, []string{"background", "base", "bg", "color0"}},
+	{func(p *Palette) *string { return &p.Foreground }, []string{"foreground", "text", "fg", "color7"}},
+	{func(p *Palette) *string { return &p.Muted }, []string{"muted", "overlay0", "subtext0", "comment", "color8"}},
+	{func(p *Palette) *string { return &p.Border }, []string{"border", "surface0", "surface1", "col.inactive_border"}},
+	{func(p *Palette) *string { return &p.Accent }, []string{"accent", "col.active_border", "blue", "color4"}},
+	{func(p *Palette) *string { return &p.Warning }, []string{"warning", "yellow", "color3"}},
+	{func(p *Palette) *string { return &p.Success }, []string{"success", "green", "color2"}},
+	{func(p *Palette) *string { return &p.Error }, []string{"error", "red", "color1"}},
+	{func(p *Palette) *string { return &p.Highlight }, []string{"highlight", "mauve", "pink", "magenta", "color5"}},
+}
+
+// HyprlandConfigPath returns $XDG_CONFIG_HOME/hypr/hyprland.conf, falling back to ~/.config
+func HyprlandConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config")
+		}
+	}
+	return filepath.Join(dir, "hypr", "hyprland.conf")
+}
+
+// HyprlandPalette builds a palette from the color variables ($name = rgb(...))
+// and border colors of a Hyprland config, following source = lines.
+// Colors that are not found keep the default theme's value.
+func HyprlandPalette(path string) (Palette, error) {
+	values := make(map[string]string)
+	if err := readHyprlandColors(path, values, 0); err != nil {
+		return Palette{}, fmt.Errorf("hyprland theme: %w", err)
+	}
+
+	palette := DefaultPalette()
+	found := 0
+	for _, role := range hyprlandRoles {
+		for _, name := range role.variables {
+			if color, ok := parseHyprlandColor(values[name]); ok {
+				*role.target(&palette) = color
+				found++
+				break
+			}
+		}
+	}
+	if found == 0 {
+		return Palette{}, fmt.Errorf("hyprland theme: no color variables found in %s", path)
+	}
+	return palette, nil
+}
+
+// readHyprlandColors collects variables and border colors into values, with
+// variable references already substituted
+func readHyprlandColors(path string, values map[string]string, depth int) error {
+	if depth > maxSourceDepth {
+		return fmt.Errorf("%s: source nesting too deep", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var sections []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasSuffix(line, "{"):
+			sections = append(sections, strings.TrimSpace(strings.TrimSuffix(line, "{")))
+			continue
+		case line == "}":
+			if len(sections) > 0 {
+				sections = sections[:len(sections)-1]
+			}
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = substituteVariables(strings.TrimSpace(value), values)
+
+		switch {
+		case strings.HasPrefix(name, "$"):
+			values[strings.TrimPrefix(name, "$")] = value
+		case name == "source":
+			if err := readSourced(path, value, values, depth); err != nil {
+				return err
+			}
+		default:
+			// general { col.active_border = ... } or general:col.active_border = ...
+			key := strings.Join(append(append([]string{}, sections...), name), ":")
+			if border, ok := strings.CutPrefix(key, "general:"); ok && strings.HasPrefix(border, "col.") {
+				values[border] = value
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// stripComment drops a # comment, which starts the line or follows whitespace
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func readSourced(from, pattern string, values map[string]string, depth int) error {
+	pattern = expandHome(pattern)
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(filepath.Dir(from), pattern)
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if err := readHyprlandColors(match, values, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// substituteVariables replaces $name references. Names are read greedily so
+// $blue2 is not taken as $blue followed by 2.
+func substituteVariables(value string, values map[string]string) string {
+	for range maxSourceDepth {
+		idx := strings.Index(value, "$")
+		if idx < 0 {
+			return value
+		}
+		end := idx + 1
+		for end < len(value) && isVariableChar(value[end]) {
+			end++
+		}
+		replacement, ok := values[value[idx+1:end]]
+		if !ok {
+			return value
+		}
+		value = value[:idx] + replacement + value[end:]
+	}
+	return value
+}
+
+func isVariableChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// parseHyprlandColor converts the first color of a value to #rrggbb. Hyprland
+// writes colors as rgb(rrggbb), rgba(rrggbbaa), rgb(r, g, b), rgba(r, g, b, a)
+// or 0xaarrggbb; gradients list several colors and an angle.
+func parseHyprlandColor(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false
+	}
+	if strings.HasPrefix(value, "rgb") {
+		open := strings.Index(value, "(")
+		end := strings.Index(value, ")")
+		if open < 0 || end < open {
+			return "", false
+		}
+		args := strings.Split(value[open+1:end], ",")
+		if len(args) == 1 {
+			hex := strings.TrimSpace(args[0])
+			if len(hex) != 6 && len(hex) != 8 {
+				return "", false
+			}
+			return hexColor(hex[:6])
+		}
+		if len(args) < 3 {
+			return "", false
+		}
+		var rgb [3]int
+		for i := range rgb {
+			n, err := strconv.Atoi(strings.TrimSpace(args[i]))
+			if err != nil || n < 0 || n > 255 {
+				return "", false
+			}
+			rgb[i] = n
+		}
+		return fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2]), true
+	}
+	if hex, ok := strings.CutPrefix(strings.Fields(value)[0], "0x"); ok && len(hex) == 8 {
+		return hexColor(hex[2:])
+	}
+	return "", false
+}
+
+func hexColor(hex string) (string, bool) {
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return "", false
+	}
+	return "#" + strings.ToUpper(hex), true
+}
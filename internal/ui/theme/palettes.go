@@ -0,0 +1,92 @@
+package theme
+
+import "slices"
+
+const (
+	NameDefault    = "catppuccin-mocha"
+	NameMonochrome = "monochrome"
+	NameHyprland   = "hyprland"
+)
+
+// builtinPalettes are the themes that can be picked by name
+var builtinPalettes = map[string]Palette{
+	NameDefault: DefaultPalette(),
+	"catppuccin-macchiato": {
+		Background: "#24273A",
+		Foreground: "#CAD3F5",
+		Muted:      "#6E738D",
+		Border:     "#363A4F",
+		Accent:     "#8AADF4",
+		Warning:    "#EED49F",
+		Success:    "#A6DA95",
+		Error:      "#ED8796",
+		Highlight:  "#C6A0F6",
+	},
+	"catppuccin-frappe": {
+		Background: "#303446",
+		Foreground: "#C6D0F5",
+		Muted:      "#737994",
+		Border:     "#414559",
+		Accent:     "#8CAAEE",
+		Warning:    "#E5C890",
+		Success:    "#A6D189",
+		Error:      "#E78284",
+		Highlight:  "#CA9EE6",
+	},
+	"catppuccin-latte": {
+		Background: "#EFF1F5",
+		Foreground: "#4C4F69",
+		Muted:      "#9CA0B0",
+		Border:     "#CCD0DA",
+		Accent:     "#1E66F5",
+		Warning:    "#DF8E1D",
+		Success:    "#40A02B",
+		Error:      "#D20F39",
+		Highlight:  "#8839EF",
+	},
+	"gruvbox-dark": {
+		Background: "#282828",
+		Foreground: "#EBDBB2",
+		Muted:      "#928374",
+		Border:     "#504945",
+		Accent:     "#83A598",
+		Warning:    "#FABD2F",
+		Success:    "#B8BB26",
+		Error:      "#FB4934",
+		Highlight:  "#D3869B",
+	},
+	"nord": {
+		Background: "#2E3440",
+		Foreground: "#D8DEE9",
+		Muted:      "#616E88",
+		Border:     "#3B4252",
+		Accent:     "#88C0D0",
+		Warning:    "#EBCB8B",
+		Success:    "#A3BE8C",
+		Error:      "#BF616A",
+		Highlight:  "#B48EAD",
+	},
+	"tokyo-night": {
+		Background: "#1A1B26",
+		Foreground: "#C0CAF5",
+		Muted:      "#565F89",
+		Border:     "#292E42",
+		Accent:     "#7AA2F7",
+		Warning:    "#E0AF68",
+		Success:    "#9ECE6A",
+		Error:      "#F7768E",
+		Highlight:  "#BB9AF7",
+	},
+	NameMonochrome: {},
+}
+
+// Names lists the built-in themes plus the hyprland mode, sorted
+func Names() []string {
+	names := make([]string, 0, len(builtinPalettes)+1)
+	for name := range builtinPalettes {
+		names = append(names, name)
+	}
+	names = append(names, NameHyprland)
+	slices.Sort(names)
+	return names
+}
@@ -9,7 +9,9 @@ type Theme struct {
 	WorkspaceView WorkspaceTheme
 	ProcessView   ProcessListTheme
 	UsageBars     UsageBarTheme
+	Graph         GraphTheme
 	Help          HelpTheme
+	Dialog        DialogTheme
 	Footer        lipgloss.Style
 }
 
@@ -18,21 +20,42 @@ type WorkspaceTheme struct {
 	SelectedBox lipgloss.Style
 	Title       lipgloss.Style
 	Details     lipgloss.Style
+	Sparkline   lipgloss.Style
 }
 
 type ProcessListTheme struct {
+	Title       lipgloss.Style
+	Alert       lipgloss.Style
 	HeaderText  lipgloss.Style
 	Row         lipgloss.Style
 	SelectedRow lipgloss.Style
 }
+
+// DialogTheme styles the overlays drawn over a screen, like the kill confirmation
+type DialogTheme struct {
+	Box    lipgloss.Style
+	Title  lipgloss.Style
+	Text   lipgloss.Style
+	Hint   lipgloss.Style
+	Cursor lipgloss.Style
+}
 type ViewModelTheme struct {
 	Title lipgloss.Style
 }
 
 type UsageBarTheme struct {
-	Text    lipgloss.Style
-	Bar     lipgloss.Style
-	BarFill string
+	Text      lipgloss.Style
+	Bar       lipgloss.Style
+	Pinned    lipgloss.Style // label and value of a core the selected process is pinned to
+	PinnedBar lipgloss.Style
+	BarFill   string
+}
+
+type GraphTheme struct {
+	Title  lipgloss.Style
+	Axis   lipgloss.Style
+	Legend lipgloss.Style
+	Series []lipgloss.Style // one color per overlaid series, cycled
 }
 
 type HelpTheme struct {
@@ -40,25 +63,59 @@ type HelpTheme struct {
 	Desc lipgloss.Style
 }
 
+// Palette is the set of colors a theme is built from. An empty color leaves
+// the terminal's own color in place, which is how the monochrome theme works.
+type Palette struct {
+	Background string `toml:"background"`
+	Foreground string `toml:"foreground"`
+	Muted      string `toml:"muted"`
+	Border     string `toml:"border"`
+	Accent     string `toml:"accent"`
+	Warning    string `toml:"warning"`
+	Success    string `toml:"success"`
+	Error      string `toml:"error"`
+	Highlight  string `toml:"highlight"`
+}
+
 var theme Theme
 
-func Init() {
-	theme = NewDefaultTheme()
+func Init(p Palette) {
+	theme = NewTheme(p)
 }
 
 func Get() Theme {
 	return theme
 }
 
+func DefaultPalette() Palette {
+	return Palette{
+		Background: DefaultColorBackground,
+		Foreground: DefaultColorForeground,
+		Muted:      DefaultColorMutedText,
+		Border:     DefaultColorBorder,
+		Accent:     DefaultColorAccent,
+		Warning:    DefaultColorWarning,
+		Success:    DefaultColorSuccess,
+		Error:      DefaultColorError,
+		Highlight:  DefaultColorHighlight,
+	}
+}
+
 func NewDefaultTheme() Theme {
+	return NewTheme(DefaultPalette())
+}
+
+func NewTheme(p Palette) Theme {
 	return Theme{
-		Header:        buildHeaderTheme(DefaultColorAccent, DefaultColorForeground),
-		ViewModel:     buildViewModelTheme(DefaultColorAccent, DefaultColorForeground),
+		Header:        buildHeaderTheme(p.Accent, p.Foreground),
+		ViewModel:     buildViewModelTheme(p.Accent, p.Foreground),
 		Footer:        buildFooterTheme(),
-		WorkspaceView: buildWorkspaceTheme(DefaultColorBorder, DefaultColorAccent, DefaultColorForeground, DefaultColorMutedText),
-		ProcessView:   buildProcessListTheme(DefaultColorAccent, DefaultColorForeground),
-		UsageBars:     buildUsageBarTheme(DefaultColorMutedText, DefaultColorSuccess),
-		Help:          buildHelpTheme(DefaultColorAccent, DefaultColorMutedText),
+		WorkspaceView: buildWorkspaceTheme(p.Border, p.Accent, p.Foreground, p.Muted),
+		ProcessView:   buildProcessListTheme(p.Accent, p.Background, p.Border, p.Warning),
+		UsageBars:     buildUsageBarTheme(p.Muted, p.Success, p.Highlight),
+		Graph:         buildGraphTheme(p.Accent, p.Foreground, p.Muted, p.Accent, p.Success, p.Warning, p.Highlight),
+		Help:          buildHelpTheme(p.Accent, p.Muted),
+		Dialog:        buildDialogTheme(p.Accent, p.Foreground, p.Muted),
 	}
 }
 
@@ -82,39 +139,104 @@ func buildWorkspaceTheme(border, accent, fg, muted string) WorkspaceTheme {
 		Padding(WorkspaceBoxPadding, WorkspaceBoxPadding).
 		Align(lipgloss.Center, lipgloss.Center)
 
+	selectedBox := baseBox.BorderForeground(lipgloss.Color(accent))
+	if accent == "" {
+		// Without colors the selection has to show in the border shape
+		selectedBox = selectedBox.Border(lipgloss.ThickBorder())
+	}
+
 	return WorkspaceTheme{
 		Box:         baseBox,
-		SelectedBox: baseBox.BorderForeground(lipgloss.Color(accent)),
+		SelectedBox: selectedBox,
 		Title:       lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(fg)),
 		Details:     lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(fg)),
+		Sparkline:   lipgloss.NewStyle().Foreground(lipgloss.Color(accent)),
 	}
 }
 
-func buildProcessListTheme(accent, fg string) ProcessListTheme {
+func buildProcessListTheme(accent, bg, border, warning string) ProcessListTheme {
+	selectedRow := lipgloss.NewStyle().
+		Bold(true).
+		Background(lipgloss.Color(accent)).
+		Foreground(lipgloss.Color(bg))
+	if accent == "" {
+		selectedRow = selectedRow.Reverse(true)
+	}
+
 	return ProcessListTheme{
-		HeaderText: lipgloss.NewStyle().
+		Title: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color(accent)),
+		Alert: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(warning)),
+		HeaderText: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(accent)).
+			Padding(0, ProcessRowPadding).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderBottom(true).
+			BorderForeground(lipgloss.Color(border)),
 		Row: lipgloss.NewStyle().
-			Padding(ProcessRowPadding, ProcessRowPadding),
-		SelectedRow: lipgloss.NewStyle().
-			Padding(ProcessRowPadding, ProcessRowPadding).
-			Background(lipgloss.Color(accent)).
+			Padding(0, ProcessRowPadding),
+		SelectedRow: selectedRow,
+	}
+}
+
+func buildDialogTheme(accent, fg, muted string) DialogTheme {
+	return DialogTheme{
+		Box: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(accent)).
+			Padding(1, 2),
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(accent)),
+		Text: lipgloss.NewStyle().
 			Foreground(lipgloss.Color(fg)),
+		Hint: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(muted)).
+			Italic(true),
+		Cursor: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(accent)),
 	}
 }
 
-func buildUsageBarTheme(muted, success string) UsageBarTheme {
+func buildUsageBarTheme(muted, success, highlight string) UsageBarTheme {
 	return UsageBarTheme{
 		Text: lipgloss.NewStyle().
 			Bold(true),
 		Bar: lipgloss.NewStyle().
 			Background(lipgloss.Color(muted)).
 			Foreground(lipgloss.Color(success)),
+		Pinned: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(highlight)),
+		PinnedBar: lipgloss.NewStyle().
+			Background(lipgloss.Color(muted)).
+			Foreground(lipgloss.Color(highlight)),
 		BarFill: "█",
 	}
 }
 
+func buildGraphTheme(accent, fg, muted string, series ...string) GraphTheme {
+	seriesStyles := make([]lipgloss.Style, len(series))
+	for i, color := range series {
+		seriesStyles[i] = lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+	return GraphTheme{
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(accent)),
+		Axis: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(muted)),
+		Legend: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(fg)),
+		Series: seriesStyles,
+	}
+}
+
 func buildHelpTheme(accent, muted string) HelpTheme {
 	return HelpTheme{
 		Key: lipgloss.NewStyle().
@@ -5,8 +5,28 @@ import "github.com/paulvinueza30/hyprtask/internal/logger"
 func (v *ViewModel) handleAction(a ViewAction) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
-	v.setSortKey(a.NewSortKey)
-	v.setSortOrder(a.NewSortOrder)
+	switch a.Type {
+	case ViewActionGroup:
+		v.setGroupBy(a.NewGroupBy)
+	default:
+		v.setSortKey(a.NewSortKey)
+		v.setSortOrder(a.NewSortOrder)
+		v.setThenBy(a.NewThenBy)
+	}
+}
+
+func (v *ViewModel) setThenBy(thenBy []SortOption) {
+	if len(thenBy) > MaxThenBy {
+		logger.Log.Warn("too many tie-breaking sort keys, keeping the first ones", "count", len(thenBy))
+		thenBy = thenBy[:MaxThenBy]
+	}
+	for _, option := range thenBy {
+		if !validSortKeys[option.Key] || !validSortOrders[option.Order] {
+			logger.Log.Warn("invalid tie-breaking sort key entered", "sort option", option)
+			return
+		}
+	}
+	v.viewOptions.ThenBy = thenBy
 }
 func (v *ViewModel) setSortKey(sk SortKey) {
 	if _, ok := validSortKeys[sk]; !ok {
@@ -23,3 +43,11 @@ func (v *ViewModel) setSortOrder(so SortOrder) {
 	}
 	v.viewOptions.SortOrder = so
 }
+
+func (v *ViewModel) setGroupBy(g GroupBy) {
+	if _, ok := groupByNames[g]; !ok {
+		logger.Log.Warn("invalid grouping entered", "group by", g)
+		return
+	}
+	v.viewOptions.GroupBy = g
+}
@@ -0,0 +1,149 @@
+package viewmodel
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+// GroupBy aggregates the process list into one row per application
+type GroupBy int
+
+const (
+	GroupByNone GroupBy = iota
+	GroupByProgram
+	GroupByClass // Hyprland window class
+	GroupByUser
+	GroupByUnit // cgroup, i.e. the systemd unit
+)
+
+var groupByNames = map[GroupBy]string{
+	GroupByNone:    "none",
+	GroupByProgram: "program",
+	GroupByClass:   "class",
+	GroupByUser:    "user",
+	GroupByUnit:    "unit",
+}
+
+func (g GroupBy) String() string {
+	if name, ok := groupByNames[g]; ok {
+		return name
+	}
+	return fmt.Sprintf("GroupBy(%d)", int(g))
+}
+
+// Next returns the grouping after g, wrapping around to GroupByNone
+func (g GroupBy) Next() GroupBy {
+	return (g + 1) % GroupBy(len(groupByNames))
+}
+
+// ParseGroupBy returns the grouping with the given name, as used in the config file and flags
+func ParseGroupBy(name string) (GroupBy, error) {
+	for g, groupName := range groupByNames {
+		if groupName == name {
+			return g, nil
+		}
+	}
+	names := make([]string, 0, len(groupByNames))
+	for _, groupName := range groupByNames {
+		names = append(names, groupName)
+	}
+	slices.Sort(names)
+	return GroupByNone, fmt.Errorf("unknown grouping %q (valid: %s)", name, strings.Join(names, ", "))
+}
+
+// ProcessGroup is every listed process that shares one key
+type ProcessGroup struct {
+	Key     string                    `json:"key"`
+	Members []taskmanager.TaskProcess `json:"members"` // sorted like the process list
+	// Aggregate stands in for the group in the table and when sorting. CPU,
+	// memory, IO and threads are summed over the members, the rest is taken
+	// from the oldest member.
+	Aggregate taskmanager.TaskProcess `json:"aggregate"`
+}
+
+// noKey labels the group of processes the grouping does not apply to, e.g.
+// those without a window when grouping by class
+const noKey = "-"
+
+// groupKey returns the key proc is grouped under
+func groupKey(proc taskmanager.TaskProcess, by GroupBy) string {
+	var key string
+	switch by {
+	case GroupByProgram:
+		key = proc.ProgramName
+	case GroupByClass:
+		if proc.Meta != nil && proc.Meta.Hyprland != nil {
+			key = proc.Meta.Hyprland.Class
+		}
+	case GroupByUser:
+		key = proc.User
+	case GroupByUnit:
+		key = proc.Unit
+	}
+	if key == "" {
+		return noKey
+	}
+	return key
+}
+
+// buildGroups groups procs, which must already be sorted, and sorts the
+// groups by their aggregates. It returns nil when not grouping.
+func (v *ViewModel) buildGroups(procs []taskmanager.TaskProcess) []ProcessGroup {
+	groups := Group(procs, v.viewOptions.GroupBy)
+	if v.viewOptions.SortKey != SortByNone || len(v.viewOptions.ThenBy) > 0 {
+		slices.SortStableFunc(groups, func(a, b ProcessGroup) int {
+			return compareProcs(a.Aggregate, b.Aggregate, v.viewOptions)
+		})
+	}
+	return groups
+}
+
+// Group groups procs by, in the order their keys first appear. It returns nil
+// for GroupByNone.
+func Group(procs []taskmanager.TaskProcess, by GroupBy) []ProcessGroup {
+	if by == GroupByNone {
+		return nil
+	}
+
+	index := make(map[string]int)
+	var groups []ProcessGroup
+	for _, proc := range procs {
+		key := groupKey(proc, by)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ProcessGroup{Key: key})
+		}
+		groups[i].Members = append(groups[i].Members, proc)
+	}
+
+	for i := range groups {
+		groups[i].Aggregate = aggregate(groups[i].Members)
+	}
+	return groups
+}
+
+func aggregate(members []taskmanager.TaskProcess) taskmanager.TaskProcess {
+	oldest := members[0]
+	for _, proc := range members[1:] {
+		if proc.StartTime.Before(oldest.StartTime) || (proc.StartTime.Equal(oldest.StartTime) && proc.PID < oldest.PID) {
+			oldest = proc
+		}
+	}
+
+	agg := oldest
+	agg.Threads = 0
+	agg.Metrics.CPU, agg.Metrics.MEM, agg.Metrics.IORead, agg.Metrics.IOWrite = 0, 0, 0, 0
+	for _, proc := range members {
+		agg.Threads += proc.Threads
+		agg.Metrics.CPU += proc.Metrics.CPU
+		agg.Metrics.MEM += proc.Metrics.MEM
+		agg.Metrics.IORead += proc.Metrics.IORead
+		agg.Metrics.IOWrite += proc.Metrics.IOWrite
+	}
+	return agg
+}
@@ -1,6 +1,13 @@
 package viewmodel
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/metrics"
 	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
 )
 
@@ -13,7 +20,17 @@ const (
 	SortByProgramName
 	SortByCPU
 	SortByMEM
-	// SortByWorkspace
+	SortByState
+	SortByThreads
+	SortByNice
+	SortByPriority
+	SortByStartTime
+	SortByPPID
+	SortByTTY
+	SortByExe
+	SortByWorkspace
+	SortByMonitor
+	SortByClass // Hyprland window class
 )
 
 var validSortKeys = map[SortKey]bool{
@@ -23,6 +40,64 @@ var validSortKeys = map[SortKey]bool{
 	SortByProgramName: true,
 	SortByCPU:  true,
 	SortByMEM:  true,
+	SortByState:     true,
+	SortByThreads:   true,
+	SortByNice:      true,
+	SortByPriority:  true,
+	SortByStartTime: true,
+	SortByPPID:      true,
+	SortByTTY:       true,
+	SortByExe:       true,
+	SortByWorkspace: true,
+	SortByMonitor:   true,
+	SortByClass:     true,
+}
+
+var sortKeyNames = map[SortKey]string{
+	SortByNone:        "none",
+	SortByPID:         "pid",
+	SortByUser:        "user",
+	SortByProgramName: "program",
+	SortByCPU:         "cpu",
+	SortByMEM:         "mem",
+	SortByState:       "state",
+	SortByThreads:     "threads",
+	SortByNice:        "nice",
+	SortByPriority:    "priority",
+	SortByStartTime:   "start_time",
+	SortByPPID:        "ppid",
+	SortByTTY:         "tty",
+	SortByExe:         "exe",
+	SortByWorkspace:   "workspace",
+	SortByMonitor:     "monitor",
+	SortByClass:       "class",
+}
+
+func (k SortKey) String() string {
+	if name, ok := sortKeyNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("SortKey(%d)", int(k))
+}
+
+// ParseSortKey returns the sort key with the given name, as used in the config file and flags
+func ParseSortKey(name string) (SortKey, error) {
+	for key, keyName := range sortKeyNames {
+		if keyName == name {
+			return key, nil
+		}
+	}
+	return SortByNone, fmt.Errorf("unknown sort key %q (valid: %s)", name, strings.Join(SortKeyNames(), ", "))
+}
+
+// SortKeyNames lists the names accepted by ParseSortKey
+func SortKeyNames() []string {
+	names := make([]string, 0, len(sortKeyNames))
+	for _, name := range sortKeyNames {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
 }
 
 type SortOrder int
@@ -39,30 +114,101 @@ var validSortOrders = map[SortOrder]bool{
 	OrderDESC: true,
 }
 
+var sortOrderNames = map[SortOrder]string{
+	OrderNone: "none",
+	OrderASC:  "asc",
+	OrderDESC: "desc",
+}
+
+func (o SortOrder) String() string {
+	if name, ok := sortOrderNames[o]; ok {
+		return name
+	}
+	return fmt.Sprintf("SortOrder(%d)", int(o))
+}
+
+// ParseSortOrder returns the sort order with the given name: none, asc or desc
+func ParseSortOrder(name string) (SortOrder, error) {
+	for order, orderName := range sortOrderNames {
+		if orderName == name {
+			return order, nil
+		}
+	}
+	return OrderNone, fmt.Errorf("unknown sort order %q (valid: asc, desc, none)", name)
+}
+
+// ParseViewOptions builds view options from sort key and order names. A key
+// without an order sorts descending, matching the process list's sort keys.
+func ParseViewOptions(keyName, orderName string) (ViewOptions, error) {
+	key, err := ParseSortKey(keyName)
+	if err != nil {
+		return ViewOptions{}, err
+	}
+	order, err := ParseSortOrder(orderName)
+	if err != nil {
+		return ViewOptions{}, err
+	}
+	if key == SortByNone {
+		order = OrderNone
+	} else if order == OrderNone {
+		order = OrderDESC
+	}
+	return ViewOptions{SortKey: key, SortOrder: order}, nil
+}
+
+type ViewActionType int
+
+const (
+	ViewActionSort  ViewActionType = iota // NewSortKey and NewSortOrder
+	ViewActionGroup                       // NewGroupBy
+)
+
+// MaxThenBy is how many tie-breaking sort keys can follow the main one
+const MaxThenBy = 2
+
+// SortOption is one sort key with its direction
+type SortOption struct {
+	Key   SortKey
+	Order SortOrder
+}
+
 type ViewAction struct {
-	NewSortKey SortKey
+	Type         ViewActionType
+	NewSortKey   SortKey
 	NewSortOrder SortOrder
+	NewThenBy    []SortOption
+	NewGroupBy   GroupBy
 }
 type ViewOptions struct {
 	SortKey SortKey
 	SortOrder SortOrder
+	ThenBy    []SortOption // breaks ties of SortKey, in priority order
+	GroupBy   GroupBy
 }
 type WorkspaceData struct {
-	ActiveProcs      []taskmanager.TaskProcess
-	ActiveProcsCount int
-	TotalCPU         float64
-	TotalMEM         float64
-	WorkspaceName    string
-	WorkspaceID      int
+	ActiveProcs      []taskmanager.TaskProcess `json:"processes"`
+	Groups           []ProcessGroup            `json:"groups,omitempty"` // ActiveProcs grouped, nil unless grouping
+	ActiveProcsCount int                       `json:"process_count"`
+	TotalCPU         float64                   `json:"cpu"`
+	TotalMEM         float64                   `json:"mem"`
+	WorkspaceName    string                    `json:"name"`
+	WorkspaceID      int                       `json:"id"`
 }
 
 type WorkspaceDisplayData struct {
-	WorkspaceToProcs map[int]*WorkspaceData // workspace id -> procs in workspace
-	Workspaces       []*WorkspaceData
-	WorkspaceCount   int
+	WorkspaceToProcs map[int]*WorkspaceData `json:"-"` // workspace id -> procs in workspace
+	Workspaces       []*WorkspaceData       `json:"workspaces"`
+	WorkspaceCount   int                    `json:"count"`
 }
 
 type DisplayData struct {
-	All  []taskmanager.TaskProcess
-	Hypr WorkspaceDisplayData
+	All     []taskmanager.TaskProcess
+	Groups  []ProcessGroup // All grouped, nil unless grouping
+	GroupBy GroupBy
+	Hypr    WorkspaceDisplayData
+	System  metrics.SystemUsage
+	Accurate bool // built from a fully sampled snapshot, see taskmanager.Snapshot
+	Timestamp time.Time // when the snapshot was taken
+
+	History *history.Store // shared, read-only for consumers
 }
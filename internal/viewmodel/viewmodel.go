@@ -5,6 +5,8 @@ import (
 	"slices"
 	"sync"
 
+	"github.com/paulvinueza30/hyprtask/internal/history"
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
 	"github.com/paulvinueza30/hyprtask/internal/logger"
 	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
 )
@@ -18,21 +20,29 @@ type ViewModel struct {
 
 	currentSnapshot *taskmanager.Snapshot
 	displayData     DisplayData
+	history         *history.Store
 
 	mu sync.RWMutex
 }
 
-func NewViewModel(ssChan chan taskmanager.Snapshot, acChan chan ViewAction, ddChan chan DisplayData) *ViewModel {
-	viewOptions := ViewOptions{SortKey: SortByNone, SortOrder: OrderNone}
+// NewViewModel starts out sorting by viewOptions
+func NewViewModel(ssChan chan taskmanager.Snapshot, acChan chan ViewAction, ddChan chan DisplayData, viewOptions ViewOptions) *ViewModel {
 	return &ViewModel{
 		snapshotChan:    ssChan,
 		actionChan:      acChan,
 		displayDataChan: ddChan,
 		viewOptions: viewOptions,
 		currentSnapshot: nil,
+		history:         history.NewStore(history.DefaultCapacity),
 	}
 }
 
+// UseHistory replaces the empty history the view model starts with, e.g. by
+// the daemon's. Call it before Start.
+func (v *ViewModel) UseHistory(store *history.Store) {
+	v.history = store
+}
+
 func (v *ViewModel) Start() {
 	for {
 		select {
@@ -62,6 +72,10 @@ func (v *ViewModel) updateSnapshot(s taskmanager.Snapshot) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	v.currentSnapshot = &s
+	// Quick snapshots carry placeholder CPU values, only sample accurate ones
+	if s.Accurate {
+		v.history.Record(s)
+	}
 }
 
 func (v *ViewModel) processSnapshot() {
@@ -76,7 +90,16 @@ func (v *ViewModel) rebuildDisplayData() {
 	wsDisplayData := v.buildWorkspaceDisplayData(procs)
 	v.applyViewOptions(procs)
 
-	v.displayData = DisplayData{All: procs, Hypr: wsDisplayData}
+	v.displayData = DisplayData{
+		All:     procs,
+		Groups:  v.buildGroups(procs),
+		GroupBy: v.viewOptions.GroupBy,
+		Hypr:    wsDisplayData,
+		System:  v.currentSnapshot.System,
+		Accurate: v.currentSnapshot.Accurate,
+		Timestamp: v.currentSnapshot.Timestamp,
+		History: v.history,
+	}
 
 	// Send DisplayData to UI
 	v.sendDisplayData()
@@ -96,29 +119,92 @@ func (v *ViewModel) applyViewOptions(procs []taskmanager.TaskProcess) {
 		return
 	}
 	viewOpts := v.viewOptions
-	if viewOpts.SortKey == SortByNone {
+	if viewOpts.SortKey == SortByNone && len(viewOpts.ThenBy) == 0 {
 		return
 	}
 
 	slices.SortStableFunc(procs, func(a, b taskmanager.TaskProcess) int {
-		var less int
-		switch viewOpts.SortKey{
-		case SortByCPU:
-			less = cmp.Compare(a.Metrics.CPU, b.Metrics.CPU)
-		case SortByProgramName:
-			less = cmp.Compare(a.ProgramName, b.ProgramName)
-		case SortByUser:
-			less = cmp.Compare(a.User, b.User)
-		case SortByMEM:
-			less = cmp.Compare(a.Metrics.MEM, b.Metrics.MEM)
-		case SortByPID:
-			less = cmp.Compare(a.PID, b.PID)
+		return compareProcs(a, b, viewOpts)
+	})
+}
+
+// compareProcs orders a and b by the sort keys of opts, the main one first
+func compareProcs(a, b taskmanager.TaskProcess, opts ViewOptions) int {
+	if c := compareBy(a, b, SortOption{Key: opts.SortKey, Order: opts.SortOrder}); c != 0 {
+		return c
+	}
+	for _, option := range opts.ThenBy {
+		if c := compareBy(a, b, option); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareBy(a, b taskmanager.TaskProcess, option SortOption) int {
+	var less int
+	switch option.Key {
+	case SortByNone:
+		return 0
+	case SortByCPU:
+		less = cmp.Compare(a.Metrics.CPU, b.Metrics.CPU)
+	case SortByProgramName:
+		less = cmp.Compare(a.ProgramName, b.ProgramName)
+	case SortByUser:
+		less = cmp.Compare(a.User, b.User)
+	case SortByMEM:
+		less = cmp.Compare(a.Metrics.MEM, b.Metrics.MEM)
+	case SortByPID:
+		less = cmp.Compare(a.PID, b.PID)
+	case SortByState:
+		less = cmp.Compare(stateSeverity(a.State), stateSeverity(b.State))
+	case SortByThreads:
+		less = cmp.Compare(a.Threads, b.Threads)
+	case SortByNice:
+		less = cmp.Compare(a.Nice, b.Nice)
+	case SortByPriority:
+		less = cmp.Compare(a.Priority, b.Priority)
+	case SortByStartTime:
+		less = a.StartTime.Compare(b.StartTime)
+	case SortByPPID:
+		less = cmp.Compare(a.PPID, b.PPID)
+	case SortByTTY:
+		less = cmp.Compare(a.TTY, b.TTY)
+	case SortByExe:
+		less = cmp.Compare(a.Exe, b.Exe)
+	case SortByWorkspace, SortByMonitor, SortByClass:
+		wa, wb := window(a), window(b)
+		// Processes without a window go last in either order
+		if wa == nil || wb == nil {
+			return cmp.Compare(boolRank(wa == nil), boolRank(wb == nil))
 		}
-		if viewOpts.SortOrder == OrderASC {
-			return less
+		switch option.Key {
+		case SortByWorkspace:
+			less = cmp.Compare(wa.Workspace.ID, wb.Workspace.ID)
+		case SortByMonitor:
+			less = cmp.Compare(wa.Monitor, wb.Monitor)
+		default:
+			less = cmp.Compare(wa.Class, wb.Class)
 		}
-		return -less
-	})
+	}
+	if option.Order == OrderASC {
+		return less
+	}
+	return -less
+}
+
+func window(proc taskmanager.TaskProcess) *hypr.HyprlandMeta {
+	if proc.Meta == nil {
+		return nil
+	}
+	return proc.Meta.Hyprland
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
 }
 
 func (v *ViewModel) buildWorkspaceDisplayData(procs []taskmanager.TaskProcess) WorkspaceDisplayData {
@@ -149,6 +235,7 @@ func (v *ViewModel) buildWorkspaceDisplayData(procs []taskmanager.TaskProcess) W
 	for _, wsData := range workspaceToWorkspaceData {
 		wsData.ActiveProcsCount = len(wsData.ActiveProcs)
 		v.applyViewOptions(wsData.ActiveProcs)
+		wsData.Groups = v.buildGroups(wsData.ActiveProcs)
 		workspaces = append(workspaces, wsData)
 	}
 	// Sort workspaces by name for consistent ordering
@@ -156,4 +243,25 @@ func (v *ViewModel) buildWorkspaceDisplayData(procs []taskmanager.TaskProcess) W
 		return cmp.Compare(a.WorkspaceName, b.WorkspaceName)
 	})
 	return WorkspaceDisplayData{WorkspaceCount: workspaceCount, WorkspaceToProcs: workspaceToWorkspaceData, Workspaces: workspaces}
-}
\ No newline at end of file
+}
+
+// stateSeverity ranks process states so that sorting descending by state
+// brings zombies and uninterruptible sleepers to the top
+func stateSeverity(state string) int {
+	switch state {
+	case taskmanager.StateZombie:
+		return 6
+	case taskmanager.StateUninterruptible:
+		return 5
+	case taskmanager.StateRunning:
+		return 4
+	case taskmanager.StateStopped, taskmanager.StateTracingStop:
+		return 3
+	case taskmanager.StateSleeping:
+		return 2
+	case taskmanager.StateIdle:
+		return 1
+	default:
+		return 0
+	}
+}
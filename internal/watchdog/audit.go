@@ -0,0 +1,52 @@
+package watchdog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+)
+
+// auditEntry is one line of the audit log
+type auditEntry struct {
+	Time    time.Time `json:"time"` // of the snapshot that tripped the rule
+	Rule    string    `json:"rule"`
+	Subject string    `json:"subject"` // "firefox (1234)" or "workspace 9"
+	Metric  string    `json:"metric"`
+	Value   float64   `json:"value"`
+	Above   float64   `json:"above"`
+	Action  string    `json:"action"`
+	Signal  string    `json:"signal,omitempty"`
+	PIDs    []int     `json:"pids"`
+	DryRun  bool      `json:"dry_run"`
+	Errors  []string  `json:"errors,omitempty"` // "pid: error" of the processes the action failed for
+}
+
+// auditLog appends entries to a JSON lines file
+type auditLog struct {
+	file *os.File
+	enc  *json.Encoder
+}
+
+func openAuditLog(path string) (*auditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLog{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+func (a *auditLog) write(entry auditEntry) {
+	if err := a.enc.Encode(entry); err != nil {
+		logger.Log.Error("could not write the watchdog audit log", "error", err)
+	}
+}
+
+func (a *auditLog) Close() error {
+	return a.file.Close()
+}
@@ -0,0 +1,111 @@
+package watchdog
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+// self is left alone, a rule for every busy process would otherwise stop hyprtask too
+var self = os.Getpid()
+
+// rule is a validated rule of the config
+type rule struct {
+	config.RuleConfig
+	signal     syscall.Signal
+	clearBelow float64
+}
+
+// subject is what a rule looks at: a process, or the matching processes of a workspace
+type subject struct {
+	key   string // the same in every snapshot
+	name  string // for the audit log
+	value float64
+	procs []taskmanager.TaskProcess
+}
+
+func compile(cfg config.RuleConfig) (rule, error) {
+	r := rule{RuleConfig: cfg, clearBelow: cfg.ClearBelow}
+	if r.Scope == "" {
+		r.Scope = config.RuleScopeProcess
+	}
+	if r.clearBelow == 0 {
+		r.clearBelow = r.Above
+	}
+	if r.Action == config.RuleActionSignal {
+		signal, err := taskmanager.ParseSignal(r.Signal)
+		if err != nil {
+			return rule{}, fmt.Errorf("watchdog rule %q: %w", r.Name, err)
+		}
+		r.signal = signal
+	}
+	return r, nil
+}
+
+func (r rule) matches(p taskmanager.TaskProcess) bool {
+	if p.PID == self {
+		return false
+	}
+	if r.Program != "" && p.ProgramName != r.Program {
+		return false
+	}
+	if r.User != "" && p.User != r.User {
+		return false
+	}
+	if r.Class == "" && r.Workspace == "" && r.Scope == config.RuleScopeProcess {
+		return true
+	}
+
+	if p.Meta == nil || p.Meta.Hyprland == nil {
+		return false
+	}
+	w := p.Meta.Hyprland
+	if r.Workspace != "" && w.Workspace.Name != r.Workspace && strconv.Itoa(w.Workspace.ID) != r.Workspace {
+		return false
+	}
+	return r.Class == "" || strings.EqualFold(w.Class, r.Class)
+}
+
+func (r rule) metric(p taskmanager.TaskProcess) float64 {
+	if r.Metric == config.RuleMetricMEM {
+		return p.Metrics.MEM
+	}
+	return p.Metrics.CPU
+}
+
+// subjects returns every matching process, or for the workspace scope every
+// workspace with matching windows and the sum over them
+func (r rule) subjects(procs []taskmanager.TaskProcess) []subject {
+	var subjects []subject
+	byWorkspace := make(map[int]int) // workspace ID to index in subjects
+	for _, p := range procs {
+		if !r.matches(p) {
+			continue
+		}
+		if r.Scope == config.RuleScopeProcess {
+			subjects = append(subjects, subject{
+				key:   fmt.Sprintf("%d@%d", p.PID, p.StartTime.UnixNano()),
+				name:  fmt.Sprintf("%s (%d)", p.ProgramName, p.PID),
+				value: r.metric(p),
+				procs: []taskmanager.TaskProcess{p},
+			})
+			continue
+		}
+
+		ws := p.Meta.Hyprland.Workspace
+		i, ok := byWorkspace[ws.ID]
+		if !ok {
+			i = len(subjects)
+			byWorkspace[ws.ID] = i
+			subjects = append(subjects, subject{key: strconv.Itoa(ws.ID), name: "workspace " + ws.Name})
+		}
+		subjects[i].value += r.metric(p)
+		subjects[i].procs = append(subjects[i].procs, p)
+	}
+	return subjects
+}
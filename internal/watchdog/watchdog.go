@@ -0,0 +1,231 @@
+// Package watchdog evaluates the rules of the [watchdog] config table against
+// every fully sampled snapshot and takes their actions, e.g. stopping a
+// browser that holds more than 30% of memory for a minute.
+//
+// A rule trips once its metric has stayed above the threshold for the rule's
+// duration, and resets only when the metric drops below clear_below, so a
+// value hovering around the threshold does not trip it again and again. After
+// an action the rule waits at least its cooldown before acting on the same
+// process or workspace again; without a cooldown it waits for the reset.
+// Every triggered rule, dry runs included, is appended to the audit log, and
+// notify rules, actions and their failures are shown as desktop notifications.
+package watchdog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/notify"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"golang.org/x/sys/unix"
+)
+
+// Engine evaluates rules and acts through a task manager
+type Engine struct {
+	rules    []rule
+	dryRun   bool
+	do       func(taskmanager.TaskAction) taskmanager.ActionResult
+	audit    *auditLog
+	notifier *notify.Notifier
+	states   map[stateKey]*state
+}
+
+type stateKey struct {
+	rule    int
+	subject string
+}
+
+// state tracks one rule for one subject
+type state struct {
+	since     time.Time // when the metric went above the threshold, zero once it reset
+	fired     bool      // acted since the metric went above
+	lastFired time.Time
+}
+
+// NewEngine compiles the rules of cfg and opens the audit log. Actions are
+// run by do, such as TaskManager.Do. notifier may be nil.
+func NewEngine(cfg config.WatchdogConfig, do func(taskmanager.TaskAction) taskmanager.ActionResult, notifier *notify.Notifier) (*Engine, error) {
+	e := &Engine{dryRun: cfg.DryRun, do: do, notifier: notifier, states: make(map[stateKey]*state)}
+	for _, rc := range cfg.Rules {
+		r, err := compile(rc)
+		if err != nil {
+			return nil, err
+		}
+		e.rules = append(e.rules, r)
+	}
+	audit, err := openAuditLog(cfg.AuditLog)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the watchdog audit log: %w", err)
+	}
+	e.audit = audit
+	return e, nil
+}
+
+// Start evaluates every snapshot of tm in the background. Without rules it
+// does nothing.
+func Start(cfg config.WatchdogConfig, tm *taskmanager.TaskManager, notifier *notify.Notifier) error {
+	if len(cfg.Rules) == 0 {
+		return nil
+	}
+	e, err := NewEngine(cfg, tm.Do, notifier)
+	if err != nil {
+		return err
+	}
+	snapshots, _ := tm.Subscribe()
+	go e.Run(snapshots)
+	logger.Log.Info("watchdog started", "rules", len(e.rules), "dry_run", e.dryRun)
+	return nil
+}
+
+// Run evaluates snapshots until the channel is closed
+func (e *Engine) Run(snapshots <-chan taskmanager.Snapshot) {
+	for snapshot := range snapshots {
+		e.Evaluate(snapshot)
+	}
+	e.audit.Close()
+}
+
+// Evaluate checks every rule against snapshot and acts on those that trip.
+// Quick snapshots are skipped, their CPU is a placeholder. Durations are
+// measured in snapshot time.
+func (e *Engine) Evaluate(snapshot taskmanager.Snapshot) {
+	if !snapshot.Accurate {
+		return
+	}
+	now := snapshot.Timestamp
+	seen := make(map[stateKey]bool)
+	for i, r := range e.rules {
+		for _, s := range r.subjects(snapshot.Processes) {
+			key := stateKey{rule: i, subject: s.key}
+			seen[key] = true
+			st, ok := e.states[key]
+			if !ok {
+				if s.value <= r.Above {
+					continue
+				}
+				st = &state{}
+				e.states[key] = st
+			}
+			if st.update(r, s.value, now) {
+				e.act(r, s, now)
+			}
+		}
+	}
+	// processes that exited and workspaces that emptied start over
+	for key := range e.states {
+		if !seen[key] {
+			delete(e.states, key)
+		}
+	}
+}
+
+// update records value and reports whether the rule should act now
+func (st *state) update(r rule, value float64, now time.Time) bool {
+	switch {
+	case value > r.Above:
+		if st.since.IsZero() {
+			st.since = now
+		}
+	case value < r.clearBelow:
+		st.since, st.fired = time.Time{}, false
+		return false
+	}
+	if st.since.IsZero() || now.Sub(st.since) < r.For.Duration {
+		return false
+	}
+	if !st.lastFired.IsZero() && now.Sub(st.lastFired) < r.Cooldown.Duration {
+		return false
+	}
+	if st.fired && r.Cooldown.Duration == 0 {
+		return false
+	}
+	st.fired, st.lastFired = true, now
+	return true
+}
+
+func (e *Engine) act(r rule, s subject, now time.Time) {
+	entry := auditEntry{
+		Time:    now,
+		Rule:    r.Name,
+		Subject: s.name,
+		Metric:  r.Metric,
+		Value:   s.value,
+		Above:   r.Above,
+		Action:  r.Action,
+		DryRun:  e.dryRun || r.DryRun,
+	}
+	var action *taskmanager.TaskAction
+	switch r.Action {
+	case config.RuleActionSignal:
+		entry.Signal = unix.SignalName(r.signal)
+		entry.PIDs = pids(s.procs, false)
+		action = &taskmanager.TaskAction{Type: taskmanager.TaskActionSignal, Payload: taskmanager.SignalPayload{PIDs: entry.PIDs, Signal: r.signal}}
+	case config.RuleActionCloseWindows:
+		entry.PIDs = pids(s.procs, true)
+		action = &taskmanager.TaskAction{Type: taskmanager.TaskActionCloseWindow, Payload: taskmanager.CloseWindowPayload{PIDs: entry.PIDs}}
+	case config.RuleActionNotify:
+		entry.PIDs = pids(s.procs, false)
+	}
+
+	if action != nil && !entry.DryRun && len(entry.PIDs) > 0 {
+		result := e.do(*action)
+		for _, item := range result.Items {
+			if item.Err != nil {
+				entry.Errors = append(entry.Errors, fmt.Sprintf("%d: %v", item.PID, item.Err))
+			}
+		}
+	}
+	logger.Log.Info("watchdog rule triggered", "rule", r.Name, "subject", s.name, "action", r.Action, "dry_run", entry.DryRun, "failed", len(entry.Errors))
+	e.audit.write(entry)
+	if !entry.DryRun {
+		if err := e.notifier.Notify(notification(entry, s)); err != nil {
+			logger.Log.Error("could not notify of a watchdog rule", "rule", r.Name, "error", err)
+		}
+	}
+}
+
+// notification describes what a rule did: an alert for notify rules, a quiet
+// note for actions and an urgent one when they failed
+func notification(entry auditEntry, s subject) notify.Notification {
+	n := notify.Notification{
+		Summary: "hyprtask: " + entry.Rule,
+		Key:     "watchdog:" + entry.Rule + ":" + s.key,
+	}
+	if windows := pids(s.procs, true); len(windows) > 0 {
+		n.FocusPID = windows[0]
+	}
+	usage := fmt.Sprintf("%s %s at %.1f%%, above %g%%", s.name, entry.Metric, entry.Value, entry.Above)
+
+	var did string
+	switch entry.Action {
+	case config.RuleActionNotify:
+		n.Body, n.Urgency = usage, notify.UrgencyNormal
+		return n
+	case config.RuleActionSignal:
+		did = "Sent " + entry.Signal + " to " + s.name
+	case config.RuleActionCloseWindows:
+		did = "Closed the windows of " + s.name
+	}
+	if len(entry.Errors) > 0 {
+		n.Body = fmt.Sprintf("%s\nCould not act on %d of %d processes: %s", usage, len(entry.Errors), len(entry.PIDs), entry.Errors[0])
+		n.Urgency = notify.UrgencyCritical
+		return n
+	}
+	n.Body = usage + "\n" + did
+	n.Urgency = notify.UrgencyLow
+	return n
+}
+
+// pids lists the PIDs of procs, only those with a window when windows is set
+func pids(procs []taskmanager.TaskProcess, windows bool) []int {
+	var pids []int
+	for _, p := range procs {
+		if windows && (p.Meta == nil || p.Meta.Hyprland == nil) {
+			continue
+		}
+		pids = append(pids, p.PID)
+	}
+	return pids
+}
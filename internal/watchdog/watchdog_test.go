@@ -0,0 +1,227 @@
+package watchdog
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/paulvinueza30/hyprtask/internal/config"
+	"github.com/paulvinueza30/hyprtask/internal/logger"
+	"github.com/paulvinueza30/hyprtask/internal/metrics"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitDiscard()
+	os.Exit(m.Run())
+}
+
+var start = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// step is one accurate snapshot, at seconds after start, with the CPU of the
+// watched process, or without it when absent is set
+type step struct {
+	at     int
+	cpu    float64
+	absent bool
+	fires  bool
+}
+
+func snapshotAt(s step) taskmanager.Snapshot {
+	snapshot := taskmanager.Snapshot{Timestamp: start.Add(time.Duration(s.at) * time.Second), Accurate: true}
+	if !s.absent {
+		snapshot.Processes = []taskmanager.TaskProcess{{
+			PID:         4242,
+			ProgramName: "busy",
+			StartTime:   start.Add(-time.Hour),
+			Metrics:     metrics.Metrics{CPU: s.cpu},
+		}}
+	}
+	return snapshot
+}
+
+// newTestEngine returns an engine for one rule stopping busy processes, and
+// the actions it took
+func newTestEngine(t *testing.T, rc config.RuleConfig, dryRun bool) (*Engine, *[]taskmanager.TaskAction, string) {
+	t.Helper()
+	rc.Name = "busy"
+	rc.Metric = config.RuleMetricCPU
+	rc.Action = config.RuleActionSignal
+	rc.Signal = "STOP"
+	if rc.Above == 0 {
+		rc.Above = 50
+	}
+	audit := filepath.Join(t.TempDir(), "watchdog.log")
+	var actions []taskmanager.TaskAction
+	do := func(action taskmanager.TaskAction) taskmanager.ActionResult {
+		actions = append(actions, action)
+		return taskmanager.ActionResult{Type: action.Type, Items: []taskmanager.ItemResult{{PID: 4242}}}
+	}
+	cfg := config.WatchdogConfig{DryRun: dryRun, AuditLog: audit, Rules: []config.RuleConfig{rc}}
+	e, err := NewEngine(cfg, do, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { e.audit.Close() })
+	return e, &actions, audit
+}
+
+func TestEvaluate(t *testing.T) {
+	seconds := func(n int) config.Duration { return config.Duration{Duration: time.Duration(n) * time.Second} }
+	tests := []struct {
+		name  string
+		rule  config.RuleConfig
+		steps []step
+	}{
+		{
+			name: "trips only after for",
+			rule: config.RuleConfig{For: seconds(10)},
+			steps: []step{
+				{at: 0, cpu: 80},
+				{at: 5, cpu: 80},
+				{at: 10, cpu: 80, fires: true},
+				{at: 15, cpu: 80},
+			},
+		},
+		{
+			name: "dropping below above restarts for",
+			rule: config.RuleConfig{For: seconds(10)},
+			steps: []step{
+				{at: 0, cpu: 80},
+				{at: 5, cpu: 10},
+				{at: 10, cpu: 80},
+				{at: 15, cpu: 80},
+				{at: 20, cpu: 80, fires: true},
+			},
+		},
+		{
+			name: "no re-fire until clear_below",
+			rule: config.RuleConfig{ClearBelow: 20},
+			steps: []step{
+				{at: 0, cpu: 80, fires: true},
+				{at: 5, cpu: 40},
+				{at: 10, cpu: 80},
+				{at: 15, cpu: 10},
+				{at: 20, cpu: 80, fires: true},
+			},
+		},
+		{
+			name: "cooldown re-fire",
+			rule: config.RuleConfig{Cooldown: seconds(30)},
+			steps: []step{
+				{at: 0, cpu: 80, fires: true},
+				{at: 10, cpu: 80},
+				{at: 29, cpu: 80},
+				{at: 30, cpu: 80, fires: true},
+				{at: 40, cpu: 80},
+				{at: 60, cpu: 80, fires: true},
+			},
+		},
+		{
+			name: "cooldown holds across a reset",
+			rule: config.RuleConfig{Cooldown: seconds(30)},
+			steps: []step{
+				{at: 0, cpu: 80, fires: true},
+				{at: 5, cpu: 10},
+				{at: 10, cpu: 80},
+				{at: 30, cpu: 80, fires: true},
+			},
+		},
+		{
+			name: "state is dropped when the process disappears",
+			rule: config.RuleConfig{For: seconds(10)},
+			steps: []step{
+				{at: 0, cpu: 80},
+				{at: 5, absent: true},
+				{at: 10, cpu: 80},
+				{at: 15, cpu: 80},
+				{at: 20, cpu: 80, fires: true},
+			},
+		},
+		{
+			name: "a process that comes back is acted on again",
+			steps: []step{
+				{at: 0, cpu: 80, fires: true},
+				{at: 5, cpu: 80},
+				{at: 10, absent: true},
+				{at: 15, cpu: 80, fires: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, actions, _ := newTestEngine(t, tt.rule, false)
+			for _, s := range tt.steps {
+				before := len(*actions)
+				e.Evaluate(snapshotAt(s))
+				if fired := len(*actions) > before; fired != s.fires {
+					t.Fatalf("at %ds with cpu %g: fired = %v, want %v", s.at, s.cpu, fired, s.fires)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateActs(t *testing.T) {
+	e, actions, _ := newTestEngine(t, config.RuleConfig{}, false)
+	e.Evaluate(snapshotAt(step{cpu: 80}))
+	if len(*actions) != 1 {
+		t.Fatalf("got %d actions, want 1", len(*actions))
+	}
+	payload, ok := (*actions)[0].Payload.(taskmanager.SignalPayload)
+	if !ok || payload.Signal != syscall.SIGSTOP || len(payload.PIDs) != 1 || payload.PIDs[0] != 4242 {
+		t.Fatalf("got action %+v, want SIGSTOP to 4242", (*actions)[0])
+	}
+}
+
+func TestEvaluateSkipsQuickSnapshots(t *testing.T) {
+	e, actions, _ := newTestEngine(t, config.RuleConfig{}, false)
+	snapshot := snapshotAt(step{cpu: 80})
+	snapshot.Accurate = false
+	e.Evaluate(snapshot)
+	if len(*actions) != 0 {
+		t.Fatalf("acted on a quick snapshot: %+v", *actions)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		dryRun     bool
+		ruleDryRun bool
+	}{
+		{name: "all rules", dryRun: true},
+		{name: "one rule", ruleDryRun: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, actions, audit := newTestEngine(t, config.RuleConfig{Cooldown: config.Duration{Duration: time.Second}, DryRun: tt.ruleDryRun}, tt.dryRun)
+			for at := range 3 {
+				e.Evaluate(snapshotAt(step{at: at * 5, cpu: 80}))
+			}
+			if len(*actions) != 0 {
+				t.Fatalf("dry run called do: %+v", *actions)
+			}
+			if lines := countLines(t, audit); lines != 3 {
+				t.Fatalf("audit log has %d entries, want 3", lines)
+			}
+		})
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	lines := 0
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		lines++
+	}
+	return lines
+}
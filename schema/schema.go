@@ -0,0 +1,78 @@
+// Package schema describes the JSON that "hyprtask snapshot" writes for
+// scripts, dashboards and status bars. It prints one Document, or with
+// --stream one Document per line (NDJSON) after every poll.
+//
+// A document, shortened:
+//
+//	{
+//	  "version": 1,
+//	  "timestamp": "2025-01-02T15:04:05.999+01:00",
+//	  "accurate": true,
+//	  "system": {"cpu": 12.5, "cores": [10.1, 14.9], "mem": 41.2, "swap": 0,
+//	             "mem_total": 16624349184, "mem_used": 6849331200, "swap_total": 0, "swap_used": 0,
+//	             "load1": 0.52, "load5": 0.41, "load15": 0.3, "uptime_ns": 9000000000000},
+//	  "processes": [
+//	    {"pid": 4242, "ppid": 1, "program": "firefox", "user": "me", "command_line": "/usr/bin/firefox",
+//	     "exe": "/usr/lib/firefox/firefox", "state": "S", "threads": 90, "nice": 0, "priority": 20,
+//	     "start_time": "2025-01-02T09:00:00+01:00", "tty": "", "unit": "app-firefox.scope", "affinity": [0, 1],
+//	     "meta": {"hyprland": {"workspace": {"id": 2, "name": "2"}, "monitor": 0, "title": "…", "class": "firefox", "pid": 4242}},
+//	     "metrics": {"cpu": 3.1, "mem": 4.8, "io_read": 0, "io_write": 1024}}
+//	  ],
+//	  "hyprland": {"count": 1, "workspaces": [
+//	    {"id": 2, "name": "2", "process_count": 1, "cpu": 3.1, "mem": 4.8, "processes": [ … ]}
+//	  ]}
+//	}
+//
+// Percentages run from 0 to 100 per core, memory is in bytes, I/O in bytes per
+// second. "meta.hyprland" is null for processes without a Hyprland window and
+// "affinity" is null when it could not be read. CPU and I/O are placeholders
+// while "accurate" is false.
+//
+// Version only changes when a field is removed, renamed or changes meaning.
+// New fields can appear without a version change.
+package schema
+
+import (
+	"github.com/paulvinueza30/hyprtask/internal/hypr"
+	"github.com/paulvinueza30/hyprtask/internal/metrics"
+	"github.com/paulvinueza30/hyprtask/internal/taskmanager"
+	"github.com/paulvinueza30/hyprtask/internal/viewmodel"
+)
+
+// Version is the version of Document written by this build
+const Version = 1
+
+// The types that make up a Document. They are the ones hyprtask uses
+// internally, and their JSON tags are the schema.
+type (
+	Snapshot       = taskmanager.Snapshot
+	Process        = taskmanager.TaskProcess
+	Meta           = taskmanager.Meta
+	Window         = hypr.HyprlandMeta
+	Workspace      = hypr.Workspace
+	Metrics        = metrics.Metrics
+	System         = metrics.SystemUsage
+	Workspaces     = viewmodel.WorkspaceDisplayData
+	WorkspaceUsage = viewmodel.WorkspaceData
+)
+
+// Document is one snapshot of the system, its processes and its workspaces
+type Document struct {
+	Version int `json:"version"`
+	Snapshot
+	Hyprland Workspaces `json:"hyprland"`
+}
+
+// NewDocument builds the document for data, with processes in its order
+func NewDocument(data viewmodel.DisplayData) Document {
+	return Document{
+		Version: Version,
+		Snapshot: Snapshot{
+			Processes: data.All,
+			System:    data.System,
+			Timestamp: data.Timestamp,
+			Accurate:  data.Accurate,
+		},
+		Hyprland: data.Hypr,
+	}
+}